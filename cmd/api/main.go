@@ -4,31 +4,35 @@ import (
 	"airbnb-analytics/internal/database"
 	"airbnb-analytics/internal/handlers"
 	"airbnb-analytics/internal/middleware"
+	"airbnb-analytics/internal/migrations"
+	"airbnb-analytics/internal/repository"
 	"airbnb-analytics/internal/service"
 	"fmt"
 	"github.com/gorilla/mux"
 	"log"
 	"net/http"
 	"os"
+	_ "time/tzdata" // Embeds the time zone database for room time zones
 )
 
 // main initializes and starts the HTTP server.
 // It performs the following operations in order:
 // 1. Loads environment variables from .env file (if exists)
-// 2. Initializes database connection
+// 2. Initializes the storage backend selected by DB_DRIVER
 // 3. Sets up services and routing
 // 4. Starts HTTP server on configured port
 //
 // The server will exit if any initialization step fails.
 func main() {
 
-	// Initialize database connection
-	if err := database.InitDB(); err != nil {
-		log.Fatal("Error initializing database:", err)
+	// Initialize storage backend
+	repo, err := setupRepository()
+	if err != nil {
+		log.Fatal("Error initializing storage:", err)
 	}
 
 	// Initialize services
-	roomService := service.NewRoomService()
+	roomService := service.NewRoomService(repo)
 
 	// Initialize router
 	router := setupRouter(roomService)
@@ -37,6 +41,89 @@ func main() {
 	startServer(router)
 }
 
+// setupRepository creates the room repository selected by the DB_DRIVER
+// environment variable:
+//   - postgres (default): PostgreSQL database configured via DB_* variables
+//   - sqlite: Single-file SQLite database at DB_PATH
+//   - memory: In-memory storage, optionally seeded from the JSON or CSV
+//     file named by DB_SEED_FILE and the exchange rate CSV file named by
+//     DB_FX_FILE
+//
+// For SQL backends pending migrations are applied when AUTO_MIGRATE=true.
+// SQLite databases are local files owned by the server and are migrated
+// unless AUTO_MIGRATE=false.
+//
+// Returns:
+//   - repository.RoomRepository: Initialized repository
+//   - error: Any error encountered during initialization
+func setupRepository() (repository.RoomRepository, error) {
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case "", "postgres":
+		if err := database.InitDB(); err != nil {
+			return nil, err
+		}
+		if err := autoMigrate(driver); err != nil {
+			return nil, err
+		}
+		return repository.NewPostgresRoomRepository(database.DB), nil
+
+	case "sqlite":
+		if err := database.InitSQLite(); err != nil {
+			return nil, err
+		}
+		if err := autoMigrate(driver); err != nil {
+			return nil, err
+		}
+		return repository.NewSQLiteRoomRepository(database.DB), nil
+
+	case "memory":
+		repo := repository.NewMemoryRoomRepository()
+		if seedFile := os.Getenv("DB_SEED_FILE"); seedFile != "" {
+			if err := repo.LoadFile(seedFile); err != nil {
+				return nil, err
+			}
+			log.Printf("Loaded seed data from %s", seedFile)
+		}
+		if fxFile := os.Getenv("DB_FX_FILE"); fxFile != "" {
+			if err := repo.LoadExchangeRatesFile(fxFile); err != nil {
+				return nil, err
+			}
+			log.Printf("Loaded exchange rates from %s", fxFile)
+		}
+		log.Println("Using in-memory storage")
+		return repo, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER: %s", driver)
+	}
+}
+
+// autoMigrate applies pending schema migrations when AUTO_MIGRATE is "true".
+// Without AUTO_MIGRATE, SQLite databases are migrated and PostgreSQL
+// databases are left to cmd/migrate.
+// Parameters:
+//   - driver string: Database driver the global DB connection uses
+//
+// Returns:
+//   - error: Any error encountered while migrating
+func autoMigrate(driver string) error {
+	enabled := os.Getenv("AUTO_MIGRATE")
+	if enabled != "true" && (enabled != "" || driver != "sqlite") {
+		return nil
+	}
+
+	migrator, err := migrations.New(database.DB, driver)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up()
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+	return err
+}
+
 // setupRouter initializes and configures the HTTP router.
 // It sets up middleware and routes for the application.
 //
@@ -61,19 +148,133 @@ func setupRouter(roomService *service.RoomService) *mux.Router {
 
 // registerRoutes configures all API endpoints for the application.
 // It sets up the following routes:
-// - GET /rooms: Returns list of all available room IDs
+// - GET /rooms: Lists room records, optionally filtered by attribute
+// - POST /rooms: Creates a room record
+// - POST /rooms/import: Imports booking calendars from CSV
+// - GET /rooms/search: Searches rooms by attributes, metrics and availability
+// - GET /rooms/{roomId}/calendar: Returns the raw daily calendar of a room
+// - PUT /rooms/{roomId}/calendar: Sets booking status and rate for days or a range
+// - PATCH /rooms/{roomId}/calendar/{date}: Partially updates a single day
+// - GET /rooms/{roomId}/forecast: Forecasts monthly occupancy of a room
+// - GET /rooms/{roomId}/price-suggestions: Suggests rates for unbooked days
+// - GET /rooms/{roomId}/gaps: Returns orphan gaps and booking streaks of a room
+// - GET /rooms/{roomId}/pace: Returns the booking pace of a month versus last year
+// - POST /rooms/{roomId}/reservations: Creates a reservation and books its nights
+// - GET /rooms/{roomId}/reservations: Lists the reservations of a room
+// - GET /rooms/{roomId}/reservations/analytics: Returns stay statistics of a room
+// - GET /rooms/{roomId}: Returns the record of a room
+// - PUT /rooms/{roomId}: Replaces the attributes of a room
+// - DELETE /rooms/{roomId}: Deletes a room with its calendar and reservations
+// - GET /portfolio/analytics: Returns analytics across all or selected rooms
+// - GET /compare: Compares selected rooms against their group average
+// - GET /availability: Returns the rooms free for a whole stay
 // - GET /{roomId}: Returns analytics for a specific room
 //
 // Parameters:
 //   - router *mux.Router: Router instance to register routes on
 //   - roomService *service.RoomService: Service handling room analytics operations
 //
-// Each route supports both GET and OPTIONS methods for CORS compatibility.
+// Each route also accepts the OPTIONS method for CORS compatibility.
 func registerRoutes(router *mux.Router, roomService *service.RoomService) {
 
-	// Get all available room IDs
+	// List room records
 	router.HandleFunc("/rooms",
-		handlers.HandleGetAllRooms(roomService),
+		handlers.HandleListRooms(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Create a room record
+	router.HandleFunc("/rooms",
+		handlers.HandleCreateRoom(roomService),
+	).Methods("POST")
+
+	// Import booking calendars from CSV
+	router.HandleFunc("/rooms/import",
+		handlers.HandleImportBookings(roomService),
+	).Methods("POST", "OPTIONS")
+
+	// Search rooms by attributes, metrics and availability
+	router.HandleFunc("/rooms/search",
+		handlers.HandleSearchRooms(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Get the raw daily calendar of a room
+	router.HandleFunc("/rooms/{roomId}/calendar",
+		handlers.HandleGetCalendar(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Set booking status and rate for calendar days
+	router.HandleFunc("/rooms/{roomId}/calendar",
+		handlers.HandleUpdateCalendar(roomService),
+	).Methods("PUT")
+
+	// Partially update a single calendar day
+	router.HandleFunc("/rooms/{roomId}/calendar/{date}",
+		handlers.HandlePatchCalendarDay(roomService),
+	).Methods("PATCH", "OPTIONS")
+
+	// Forecast monthly occupancy of a room
+	router.HandleFunc("/rooms/{roomId}/forecast",
+		handlers.HandleRoomForecast(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Suggest rates for the unbooked days of a room
+	router.HandleFunc("/rooms/{roomId}/price-suggestions",
+		handlers.HandlePriceSuggestions(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Find orphan gaps and booking streaks in a room's calendar
+	router.HandleFunc("/rooms/{roomId}/gaps",
+		handlers.HandleCalendarGaps(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Get the booking pace of a month compared with last year
+	router.HandleFunc("/rooms/{roomId}/pace",
+		handlers.HandleBookingPace(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Create a reservation and book its nights
+	router.HandleFunc("/rooms/{roomId}/reservations",
+		handlers.HandleCreateReservation(roomService),
+	).Methods("POST")
+
+	// List the reservations of a room
+	router.HandleFunc("/rooms/{roomId}/reservations",
+		handlers.HandleGetReservations(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Get length of stay, lead time and check-in day statistics
+	router.HandleFunc("/rooms/{roomId}/reservations/analytics",
+		handlers.HandleReservationAnalytics(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Get the record of a room
+	router.HandleFunc("/rooms/{roomId}",
+		handlers.HandleGetRoom(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Replace the attributes of a room
+	router.HandleFunc("/rooms/{roomId}",
+		handlers.HandleUpdateRoom(roomService),
+	).Methods("PUT")
+
+	// Delete a room with its calendar, booking history and reservations
+	router.HandleFunc("/rooms/{roomId}",
+		handlers.HandleDeleteRoom(roomService),
+	).Methods("DELETE")
+
+	// Get analytics across all or selected rooms
+	router.HandleFunc("/portfolio/analytics",
+		handlers.HandlePortfolioAnalytics(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Compare selected rooms against their group average
+	router.HandleFunc("/compare",
+		handlers.HandleCompareRooms(roomService),
+	).Methods("GET", "OPTIONS")
+
+	// Find the rooms free for a whole stay
+	router.HandleFunc("/availability",
+		handlers.HandleAvailability(roomService),
 	).Methods("GET", "OPTIONS")
 
 	// Get analytics for a specific room
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"airbnb-analytics/internal/database"
+	"airbnb-analytics/internal/repository"
+	"airbnb-analytics/internal/service"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// fetchTimeout bounds the time spent downloading a feed
+const fetchTimeout = 30 * time.Second
+
+// main is the entry point of the import command.
+// It loads booking calendars or exchange rates into the database configured
+// via DB_DRIVER and the DB_* variables, and prints a report of the changes.
+//
+// Usage:
+//
+//	go run ./cmd/import -file calendar.csv
+//	go run ./cmd/import -format ics -room A123 -url http://localhost:9000/A123.ics
+//	go run ./cmd/import -format fx -file exchange_rates.csv
+func main() {
+	format := flag.String("format", "csv", "input format: csv, ics or fx (exchange rates)")
+	file := flag.String("file", "", "input file (- for stdin)")
+	url := flag.String("url", "", "URL to fetch the input from instead of -file")
+	roomID := flag.String("room", "", "room the iCalendar feed belongs to (ics only)")
+	from := flag.String("from", "", "first day to update, YYYY-MM-DD (ics only, default today)")
+	to := flag.String("to", "", "last day to update, YYYY-MM-DD (ics only, default last booked night)")
+	defaultRate := flag.Float64("default-rate", 0, "rate for days that don't exist yet (ics only)")
+	flag.Parse()
+
+	if (*file == "") == (*url == "") {
+		fmt.Fprintln(os.Stderr, "exactly one of -file or -url is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input, err := openInput(*file, *url)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer func() {
+		if err := input.Close(); err != nil {
+			log.Printf("Error closing input: %v", err)
+		}
+	}()
+
+	driver := os.Getenv("DB_DRIVER")
+	if err := database.Init(driver); err != nil {
+		log.Fatal("Error initializing database:", err)
+	}
+
+	defer func() {
+		if err := database.DB.Close(); err != nil {
+			log.Printf("Error closing database connection: %v", err)
+		}
+	}()
+
+	var repo repository.RoomRepository = repository.NewPostgresRoomRepository(database.DB)
+	if driver == "sqlite" {
+		repo = repository.NewSQLiteRoomRepository(database.DB)
+	}
+	roomService := service.NewRoomService(repo)
+
+	switch *format {
+	case "csv":
+		err = importCSV(roomService, input)
+	case "fx":
+		err = importExchangeRates(roomService, input)
+	case "ics":
+		opts := service.ICSOptions{DefaultRate: *defaultRate}
+		if opts.From, err = parseDateFlag("from", *from); err == nil {
+			if opts.To, err = parseDateFlag("to", *to); err == nil {
+				err = importICS(roomService, *roomID, input, opts)
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported format: %s", *format)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// openInput opens the import source, either a local file or a URL.
+// Parameters:
+//   - path string: Path of a local file, "-" reads from standard input
+//   - url string: URL to download the input from
+//
+// Returns:
+//   - io.ReadCloser: Reader for the input data
+//   - error: Any error encountered while opening the input
+func openInput(path, url string) (io.ReadCloser, error) {
+	if url != "" {
+		client := &http.Client{Timeout: fetchTimeout}
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching %s: %v", url, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("error fetching %s: %s", url, resp.Status)
+		}
+		return resp.Body, nil
+	}
+
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %v", path, err)
+	}
+	return file, nil
+}
+
+// parseDateFlag parses an optional YYYY-MM-DD flag value.
+// Parameters:
+//   - name string: Flag name used in error messages
+//   - value string: Flag value
+//
+// Returns:
+//   - time.Time: Parsed date, zero if value is empty
+//   - error: Error if the value is not a valid date
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-%s must be a date in YYYY-MM-DD format", name)
+	}
+	return date, nil
+}
+
+// importCSV imports CSV booking calendars and prints the resulting report.
+// Parameters:
+//   - roomService *service.RoomService: Service performing the import
+//   - input io.Reader: CSV data
+//
+// Returns:
+//   - error: Any error encountered while importing
+func importCSV(roomService *service.RoomService, input io.Reader) error {
+	report, err := roomService.ImportBookingsCSV(input)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range report.Errors {
+		fmt.Printf("line %d: %s\n", rowErr.Line, rowErr.Error)
+	}
+	fmt.Printf("rows read: %d, imported: %d, rejected: %d\n", report.RowsRead, report.Imported, report.Rejected)
+
+	return nil
+}
+
+// importExchangeRates imports CSV exchange rates and prints the resulting report.
+// Parameters:
+//   - roomService *service.RoomService: Service performing the import
+//   - input io.Reader: CSV data with rows currency,date,rate
+//
+// Returns:
+//   - error: Any error encountered while importing
+func importExchangeRates(roomService *service.RoomService, input io.Reader) error {
+	report, err := roomService.ImportExchangeRatesCSV(input)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range report.Errors {
+		fmt.Printf("line %d: %s\n", rowErr.Line, rowErr.Error)
+	}
+	fmt.Printf("rows read: %d, imported: %d, rejected: %d\n", report.RowsRead, report.Imported, report.Rejected)
+
+	return nil
+}
+
+// importICS applies an iCalendar availability feed and prints the resulting report.
+// Parameters:
+//   - roomService *service.RoomService: Service performing the ingestion
+//   - roomID string: Room the feed belongs to
+//   - input io.Reader: iCalendar data
+//   - opts service.ICSOptions: Window and defaults for the ingestion
+//
+// Returns:
+//   - error: Any error encountered while ingesting
+func importICS(roomService *service.RoomService, roomID string, input io.Reader, opts service.ICSOptions) error {
+	report, err := roomService.IngestICS(roomID, input, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("room %s: %d events, %s to %s: %d booked days, %d available days\n",
+		report.RoomID, report.Events, report.From, report.To, report.BookedDays, report.AvailableDays)
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"airbnb-analytics/internal/database"
+	"airbnb-analytics/internal/migrations"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+// usage describes the command line interface of the migrate command
+const usage = `usage: migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down [n]    revert the last n applied migrations (default 1)
+  status      list migrations and whether they are applied
+
+The database is selected with DB_DRIVER (postgres or sqlite) and the
+same DB_* variables used by the API server.`
+
+// main is the entry point of the migrate command.
+// It connects to the configured database and runs the requested subcommand.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	driver := os.Getenv("DB_DRIVER")
+	if err := database.Init(driver); err != nil {
+		log.Fatal("Error initializing database:", err)
+	}
+
+	defer func() {
+		if err := database.DB.Close(); err != nil {
+			log.Printf("Error closing database connection: %v", err)
+		}
+	}()
+
+	migrator, err := migrations.New(database.DB, driver)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := run(migrator, os.Args[1], os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes a single migrate subcommand.
+// Parameters:
+//   - migrator *migrations.Migrator: Migrator bound to the target database
+//   - command string: Subcommand name
+//   - args []string: Remaining command line arguments
+//
+// Returns:
+//   - error: Any error encountered while running the subcommand
+func run(migrator *migrations.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("applied  %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of steps: %s", args[0])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+}
@@ -54,3 +54,20 @@ func InitDB() error {
 	log.Println("Successfully connected to database")
 	return nil
 }
+
+// Init initializes the global DB connection for the given driver.
+// Parameters:
+//   - driver string: "postgres" (default when empty) or "sqlite"
+//
+// Returns:
+//   - error: Any error encountered during connection initialization
+func Init(driver string) error {
+	switch driver {
+	case "", "postgres":
+		return InitDB()
+	case "sqlite":
+		return InitSQLite()
+	default:
+		return fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
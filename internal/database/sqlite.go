@@ -0,0 +1,62 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	_ "github.com/mattn/go-sqlite3" // Required for SQLite driver
+)
+
+// defaultSQLitePath is the database file used when DB_PATH is not set
+const defaultSQLitePath = "airbnb_analytics.db"
+
+// InitSQLite opens the single-file SQLite database.
+// The connection is stored in the global DB variable. The schema is
+// managed by the migrations package.
+//
+// Optional environment variables:
+//   - DB_PATH: Path of the database file, defaults to airbnb_analytics.db
+//
+// Returns:
+//   - error: Any error encountered during connection initialization
+func InitSQLite() error {
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = defaultSQLitePath
+	}
+
+	var err error
+	DB, err = OpenSQLite(path)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Successfully opened SQLite database %s", path)
+	return nil
+}
+
+// OpenSQLite opens and verifies a SQLite database file, creating it if needed.
+// Parameters:
+//   - path string: Path of the database file
+//
+// Returns:
+//   - *sql.DB: Open database connection
+//   - error: Any error encountered while opening the database
+func OpenSQLite(path string) (*sql.DB, error) {
+	// Wait for locks instead of failing immediately on concurrent writes
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_foreign_keys=on", path))
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+
+	// SQLite allows a single writer, serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	if err = db.Ping(); err != nil {
+		return nil, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	return db, nil
+}
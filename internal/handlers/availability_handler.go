@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"net/http"
+)
+
+// HandleAvailability creates a handler for finding the rooms free for a stay.
+// Requires the check_in and check_out query parameters and supports max_rate
+// to exclude rooms with a night priced above it.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the availability endpoint
+func HandleAvailability(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checkIn, err := parseDateParam(r, "check_in")
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		checkOut, err := parseDateParam(r, "check_out")
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		maxRate, err := parseFloatParam(r, "max_rate")
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		availability, err := roomService.FindAvailability(checkIn, checkOut, maxRate)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) || errors.Is(err, service.ErrInvalidOptions) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to fetch availability", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, availability)
+	}
+}
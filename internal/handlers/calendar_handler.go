@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/service"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// maxCalendarBodySize limits the size of calendar update requests (1 MB)
+const maxCalendarBodySize = 1 << 20
+
+// HandleGetCalendar creates a handler for retrieving the raw daily calendar
+// of a room. Supports the optional query parameters from, to and fill=unknown.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the calendar endpoint
+func HandleGetCalendar(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var opts service.CalendarOptions
+		var err error
+		if opts.From, err = parseDateParam(r, "from"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.To, err = parseDateParam(r, "to"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch fill := r.URL.Query().Get("fill"); fill {
+		case "":
+		case "unknown":
+			opts.FillUnknown = true
+		default:
+			handleError(w, "fill must be \"unknown\" when given", http.StatusBadRequest)
+			return
+		}
+
+		calendar, err := roomService.GetCalendar(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to fetch calendar", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, calendar)
+	}
+}
+
+// HandleUpdateCalendar creates a handler for setting booking status and rate
+// of a room's calendar days, either as a list of days or as a date range.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the calendar update endpoint
+func HandleUpdateCalendar(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var update models.CalendarUpdate
+		if err := decodeJSONBody(w, r, &update); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		calendar, err := roomService.UpdateCalendar(mux.Vars(r)["roomId"], update)
+		if err != nil {
+			handleCalendarError(w, err)
+			return
+		}
+
+		sendJSONResponse(w, calendar)
+	}
+}
+
+// HandlePatchCalendarDay creates a handler for partially updating a single
+// calendar day of a room.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the calendar day endpoint
+func HandlePatchCalendarDay(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var patch models.CalendarDayPatch
+		if err := decodeJSONBody(w, r, &patch); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		day, err := roomService.PatchCalendarDay(vars["roomId"], vars["date"], patch)
+		if err != nil {
+			handleCalendarError(w, err)
+			return
+		}
+
+		sendJSONResponse(w, day)
+	}
+}
+
+// handleCalendarError maps calendar update errors to HTTP responses.
+// Parameters:
+//   - w http.ResponseWriter: Response writer to send error
+//   - err error: Error returned by the service
+func handleCalendarError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrInvalidCalendar) {
+		handleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	handleError(w, "failed to update calendar", http.StatusInternalServerError)
+}
+
+// decodeJSONBody decodes a size-limited JSON request body, rejecting unknown fields.
+// Parameters:
+//   - w http.ResponseWriter: Response writer used to enforce the size limit
+//   - r *http.Request: Incoming request
+//   - dst interface{}: Value to decode into
+//
+// Returns:
+//   - error: Error describing why the body is invalid
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCalendarBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return errors.New("invalid JSON body: " + err.Error())
+	}
+	return nil
+}
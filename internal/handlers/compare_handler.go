@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"net/http"
+)
+
+// HandleCompareRooms creates a handler for comparing the rooms listed in the
+// rooms query parameter. Supports the same window parameters as the room
+// analytics endpoint.
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the comparison endpoint
+func HandleCompareRooms(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		analyticsOpts, err := parseAnalyticsOptions(r)
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		opts := service.CompareOptions{
+			AnalyticsOptions: analyticsOpts,
+			RoomIDs:          parseListParam(r, "rooms"),
+		}
+
+		comparison, err := roomService.CompareRooms(opts)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, err.Error(), http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidWindow), errors.Is(err, service.ErrInvalidOptions),
+				errors.Is(err, service.ErrMissingExchangeRate):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			default:
+				handleError(w, "failed to compare rooms", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, comparison)
+	}
+}
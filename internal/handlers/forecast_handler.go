@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// HandleRoomForecast creates a handler for forecasting the monthly occupancy
+// of a room. Supports the optional query parameters as_of, months, method,
+// alpha and backtest.
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the forecast endpoint
+func HandleRoomForecast(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseForecastOptions(r)
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		forecast, err := roomService.ForecastOccupancy(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, "room not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidOptions):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrInsufficientHistory):
+				handleError(w, err.Error(), http.StatusUnprocessableEntity)
+			default:
+				handleError(w, "failed to forecast occupancy", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, forecast)
+	}
+}
+
+// parseForecastOptions reads the forecast settings from the query string.
+// Parameters:
+//   - r *http.Request: Incoming request
+//
+// Returns:
+//   - service.ForecastOptions: Parsed options, zero values select defaults
+//   - error: Error describing the first invalid parameter
+func parseForecastOptions(r *http.Request) (service.ForecastOptions, error) {
+	var opts service.ForecastOptions
+	var err error
+
+	if opts.AsOf, err = parseDateParam(r, "as_of"); err != nil {
+		return opts, err
+	}
+	if opts.Months, err = parseIntParam(r, "months"); err != nil {
+		return opts, err
+	}
+	if opts.Alpha, err = parseFloatParam(r, "alpha"); err != nil {
+		return opts, err
+	}
+	if opts.Backtest, err = parseBoolParam(r, "backtest"); err != nil {
+		return opts, err
+	}
+	opts.Method = r.URL.Query().Get("method")
+	return opts, nil
+}
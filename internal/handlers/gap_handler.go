@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// HandleCalendarGaps creates a handler for finding orphan gaps and booking
+// streaks in a room's calendar. Supports the optional query parameters
+// from, to and max_gap.
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the gaps endpoint
+func HandleCalendarGaps(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var opts service.GapOptions
+		var err error
+		if opts.From, err = parseDateParam(r, "from"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.To, err = parseDateParam(r, "to"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MaxGap, err = parseIntParam(r, "max_gap"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gaps, err := roomService.GetCalendarGaps(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, "room not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidWindow), errors.Is(err, service.ErrInvalidOptions):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			default:
+				handleError(w, "failed to analyze calendar gaps", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, gaps)
+	}
+}
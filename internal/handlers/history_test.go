@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repository"
+	"airbnb-analytics/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestInsufficientHistoryStatus(t *testing.T) {
+	repo := repository.NewMemoryRoomRepository()
+	if err := repo.CreateRoom(models.Room{RoomID: "EMPTY", RoomAttributes: models.RoomAttributes{Currency: "USD", TimeZone: "UTC"}}); err != nil {
+		t.Fatalf("creating room: %v", err)
+	}
+	roomService := service.NewRoomService(repo)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{name: "forecast", handler: HandleRoomForecast(roomService)},
+		{name: "pace", handler: HandleBookingPace(roomService)},
+		{name: "price suggestions", handler: HandlePriceSuggestions(roomService)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/rooms/EMPTY", nil), map[string]string{"roomId": "EMPTY"})
+			recorder := httptest.NewRecorder()
+			tt.handler(recorder, request)
+			if recorder.Code != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, http.StatusUnprocessableEntity, recorder.Body.String())
+			}
+		})
+	}
+}
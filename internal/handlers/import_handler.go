@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxImportSize limits the size of uploaded calendar files (10 MB)
+const maxImportSize = 10 << 20
+
+// HandleImportBookings creates a handler for bulk CSV imports of booking calendars.
+// The CSV may be sent as the raw request body or as the "file" field of a
+// multipart form. Valid rows are upserted and a per-row error report is returned.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the import endpoint
+func HandleImportBookings(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+		reader, closeFn, err := importSource(r)
+		if err != nil {
+			if isTooLarge(err) {
+				handleError(w, "import file is too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer closeFn()
+
+		report, err := roomService.ImportBookingsCSV(reader)
+		if err != nil {
+			if isTooLarge(err) {
+				handleError(w, "import file is too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			if errors.Is(err, service.ErrInvalidImport) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to import bookings", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, report)
+	}
+}
+
+// importSource returns the reader holding the uploaded CSV data.
+// Parameters:
+//   - r *http.Request: Incoming request
+//
+// Returns:
+//   - io.Reader: Reader for the CSV data
+//   - func(): Function releasing resources held by the reader
+//   - error: Error if the upload is malformed
+func importSource(r *http.Request) (io.Reader, func(), error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, func() {}, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		if isTooLarge(err) {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("multipart upload must contain a \"file\" field")
+	}
+	return file, func() { _ = file.Close() }, nil
+}
+
+// isTooLarge reports whether an error was caused by exceeding the request body limit.
+// Parameters:
+//   - err error: Error to inspect
+//
+// Returns:
+//   - bool: True if err wraps an *http.MaxBytesError
+func isTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// HandleBookingPace creates a handler for the booking pace of a room's month
+// compared with last year. Supports the optional query parameters month
+// (YYYY-MM) and days_out (comma-separated lead times).
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the pace endpoint
+func HandleBookingPace(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var opts service.PaceOptions
+		var err error
+		if opts.Month, err = parseMonthParam(r, "month"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.DaysOut, err = parseIntListParam(r, "days_out"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		pace, err := roomService.GetBookingPace(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, "room not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidOptions):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrInsufficientHistory):
+				handleError(w, err.Error(), http.StatusUnprocessableEntity)
+			default:
+				handleError(w, "failed to fetch booking pace", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, pace)
+	}
+}
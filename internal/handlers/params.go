@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDateParam reads an optional "YYYY-MM-DD" query parameter.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - time.Time: Parsed date, zero if the parameter is absent
+//   - error: Error describing an invalid value
+func parseDateParam(r *http.Request, name string) (time.Time, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+	return date, nil
+}
+
+// parseIntParam reads an optional positive integer query parameter.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - int: Parsed value, zero if the parameter is absent
+//   - error: Error describing an invalid value
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return number, nil
+}
+
+// parseListParam reads an optional comma-separated query parameter.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - []string: Trimmed, non-empty values, nil if the parameter is absent
+func parseListParam(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range strings.Split(r.URL.Query().Get(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseFloatListParam reads an optional comma-separated list of numbers.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - []float64: Parsed values, nil if the parameter is absent
+//   - error: Error describing an invalid value
+func parseFloatListParam(r *http.Request, name string) ([]float64, error) {
+	var numbers []float64
+	for _, value := range parseListParam(r, name) {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("%s must be a comma-separated list of numbers", name)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// parseFloatParam reads an optional finite number query parameter.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - float64: Parsed value, zero if the parameter is absent
+//   - error: Error describing an invalid value
+func parseFloatParam(r *http.Request, name string) (float64, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	// NaN and infinities parse as floats but would pass or fail every bound
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("%s must be a number", name)
+	}
+	return number, nil
+}
+
+// parseBoolParam reads an optional boolean query parameter such as "true" or "1".
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - bool: Parsed value, false if the parameter is absent
+//   - error: Error describing an invalid value
+func parseBoolParam(r *http.Request, name string) (bool, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return false, nil
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return flag, nil
+}
+
+// parseMonthParam reads an optional "YYYY-MM" query parameter.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - time.Time: First day of the month, zero if the parameter is absent
+//   - error: Error describing an invalid value
+func parseMonthParam(r *http.Request, name string) (time.Time, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a month in YYYY-MM format", name)
+	}
+	return month, nil
+}
+
+// parseIntListParam reads an optional comma-separated list of non-negative integers.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - []int: Parsed values, nil if the parameter is absent
+//   - error: Error describing an invalid value
+func parseIntListParam(r *http.Request, name string) ([]int, error) {
+	var numbers []int
+	for _, value := range parseListParam(r, name) {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("%s must be a comma-separated list of non-negative integers", name)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// parseOptionalFloatParam reads an optional number query parameter whose
+// absence must be distinguishable from zero, such as a filter bound.
+// Parameters:
+//   - r *http.Request: Incoming request
+//   - name string: Query parameter name
+//
+// Returns:
+//   - *float64: Parsed value, nil if the parameter is absent
+//   - error: Error describing an invalid value
+func parseOptionalFloatParam(r *http.Request, name string) (*float64, error) {
+	if strings.TrimSpace(r.URL.Query().Get(name)) == "" {
+		return nil, nil
+	}
+
+	number, err := parseFloatParam(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/repository"
+	"airbnb-analytics/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNonFiniteNumberParams(t *testing.T) {
+	roomService := service.NewRoomService(repository.NewMemoryRoomRepository())
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		target  string
+	}{
+		{name: "availability max_rate NaN", handler: HandleAvailability(roomService), target: "/availability?check_in=2025-03-01&check_out=2025-03-05&max_rate=NaN"},
+		{name: "availability max_rate Inf", handler: HandleAvailability(roomService), target: "/availability?check_in=2025-03-01&check_out=2025-03-05&max_rate=Inf"},
+		{name: "availability max_rate -Inf", handler: HandleAvailability(roomService), target: "/availability?check_in=2025-03-01&check_out=2025-03-05&max_rate=-Inf"},
+		{name: "search min_rate NaN", handler: HandleSearchRooms(roomService), target: "/rooms/search?min_rate=NaN"},
+		{name: "search max_occupancy +Inf", handler: HandleSearchRooms(roomService), target: "/rooms/search?max_occupancy=%2BInf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			tt.handler(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, http.StatusBadRequest, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"net/http"
+)
+
+// HandlePortfolioAnalytics creates a handler for analytics across all rooms
+// or the rooms listed in the optional rooms query parameter. Supports the same
+// window parameters as the room analytics endpoint and max_gap for the
+// orphan gap counts.
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the portfolio analytics endpoint
+func HandlePortfolioAnalytics(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		analyticsOpts, err := parseAnalyticsOptions(r)
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		maxGap, err := parseIntParam(r, "max_gap")
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		opts := service.PortfolioOptions{
+			AnalyticsOptions: analyticsOpts,
+			RoomIDs:          parseListParam(r, "rooms"),
+			MaxGap:           maxGap,
+		}
+
+		analytics, err := roomService.GetPortfolioAnalytics(opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) || errors.Is(err, service.ErrInvalidOptions) ||
+				errors.Is(err, service.ErrMissingExchangeRate) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to fetch portfolio analytics", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, analytics)
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/service"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// HandlePriceSuggestions creates a handler for suggesting rates for the
+// future unbooked days of a room. Supports the optional query parameters
+// as_of, from and to.
+// Parameters:
+//   - roomService *service.RoomService: Service for processing room analytics
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the price suggestion endpoint
+func HandlePriceSuggestions(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var opts service.PriceSuggestionOptions
+		var err error
+		if opts.AsOf, err = parseDateParam(r, "as_of"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.From, err = parseDateParam(r, "from"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.To, err = parseDateParam(r, "to"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		suggestions, err := roomService.SuggestPrices(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, "room not found", http.StatusNotFound)
+			case errors.Is(err, service.ErrInvalidWindow):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrInsufficientHistory):
+				handleError(w, err.Error(), http.StatusUnprocessableEntity)
+			default:
+				handleError(w, "failed to suggest prices", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, suggestions)
+	}
+}
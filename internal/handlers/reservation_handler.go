@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/service"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// HandleCreateReservation creates a handler for storing a reservation and
+// booking its nights in the room's calendar.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the reservation creation endpoint
+func HandleCreateReservation(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input models.ReservationInput
+		if err := decodeJSONBody(w, r, &input); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		reservation, err := roomService.CreateReservation(mux.Vars(r)["roomId"], input)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidReservation):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrReservationConflict):
+				handleError(w, err.Error(), http.StatusConflict)
+			default:
+				handleError(w, "failed to create reservation", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		sendJSONResponse(w, reservation)
+	}
+}
+
+// HandleGetReservations creates a handler for listing the reservations of a
+// room. Supports the optional check-in range query parameters from and to.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the reservation list endpoint
+func HandleGetReservations(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseReservationOptions(r)
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		reservations, err := roomService.GetReservations(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to fetch reservations", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, reservations)
+	}
+}
+
+// HandleReservationAnalytics creates a handler for length of stay, lead time
+// and check-in day statistics of a room's reservations. Supports the optional
+// check-in range query parameters from and to.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the reservation analytics endpoint
+func HandleReservationAnalytics(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseReservationOptions(r)
+		if err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		analytics, err := roomService.GetReservationAnalytics(mux.Vars(r)["roomId"], opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to fetch reservation analytics", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, analytics)
+	}
+}
+
+// parseReservationOptions reads the check-in range from the query string.
+// Parameters:
+//   - r *http.Request: Incoming request
+//
+// Returns:
+//   - service.ReservationOptions: Parsed options, zero values for absent parameters
+//   - error: Error describing the first invalid parameter
+func parseReservationOptions(r *http.Request) (opts service.ReservationOptions, err error) {
+	if opts.From, err = parseDateParam(r, "from"); err != nil {
+		return opts, err
+	}
+	if opts.To, err = parseDateParam(r, "to"); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
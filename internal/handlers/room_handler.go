@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"airbnb-analytics/internal/models"
 	"airbnb-analytics/internal/service"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strings"
 )
 
 // HandleRoomAnalytics creates a handler for room analytics requests.
+// The analysis windows can be adjusted with the optional query parameters
+// as_of, from, to, occupancy_months and rate_days; the reported rate
+// percentiles with percentiles, the weekend days with weekend, the
+// comparison period with compare and the currency rates are reported in
+// with currency.
 // Parameters:
 //   - roomService *service.RoomService: Service for processing room analytics
 //
@@ -25,9 +32,20 @@ func HandleRoomAnalytics(roomService *service.RoomService) http.HandlerFunc {
 			return
 		}
 
-		analytics, err := roomService.GetRoomAnalytics(roomID)
+		opts, err := parseAnalyticsOptions(r)
 		if err != nil {
-			if err.Error() == "room not found" {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		analytics, err := roomService.GetRoomAnalytics(roomID, opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) || errors.Is(err, service.ErrInvalidOptions) ||
+				errors.Is(err, service.ErrMissingExchangeRate) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, service.ErrRoomNotFound) {
 				handleError(w, "room not found", http.StatusNotFound)
 				return
 			}
@@ -39,26 +57,191 @@ func HandleRoomAnalytics(roomService *service.RoomService) http.HandlerFunc {
 	}
 }
 
-// HandleGetAllRooms creates a handler for retrieving all available room IDs.
+// parseAnalyticsOptions extracts the analysis window query parameters.
+// Parameters:
+//   - r *http.Request: Incoming request
+//
+// Returns:
+//   - service.AnalyticsOptions: Parsed options, zero values for absent parameters
+//   - error: Error describing the first invalid parameter
+func parseAnalyticsOptions(r *http.Request) (opts service.AnalyticsOptions, err error) {
+	if opts.AsOf, err = parseDateParam(r, "as_of"); err != nil {
+		return opts, err
+	}
+	if opts.From, err = parseDateParam(r, "from"); err != nil {
+		return opts, err
+	}
+	if opts.To, err = parseDateParam(r, "to"); err != nil {
+		return opts, err
+	}
+	if opts.OccupancyMonths, err = parseIntParam(r, "occupancy_months"); err != nil {
+		return opts, err
+	}
+	if opts.RateDays, err = parseIntParam(r, "rate_days"); err != nil {
+		return opts, err
+	}
+	if opts.Percentiles, err = parseFloatListParam(r, "percentiles"); err != nil {
+		return opts, err
+	}
+	for _, name := range parseListParam(r, "weekend") {
+		day, err := service.ParseWeekday(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.WeekendDays = append(opts.WeekendDays, day)
+	}
+	opts.Compare = r.URL.Query().Get("compare")
+	opts.Currency = r.URL.Query().Get("currency")
+	return opts, nil
+}
+
+// HandleListRooms creates a handler for listing room records. Supports the
+// optional filters city, neighborhood, property_type, currency, min_bedrooms
+// and min_capacity.
 // Parameters:
 //   - roomService *service.RoomService: Service for room operations
 //
 // Returns:
-//   - http.HandlerFunc: Handler function for getting all rooms endpoint
-func HandleGetAllRooms(roomService *service.RoomService) http.HandlerFunc {
+//   - http.HandlerFunc: Handler function for the room list endpoint
+func HandleListRooms(roomService *service.RoomService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		rooms, err := roomService.GetAllRooms()
+		query := r.URL.Query()
+		filter := models.RoomFilter{
+			City:         query.Get("city"),
+			Neighborhood: query.Get("neighborhood"),
+			PropertyType: query.Get("property_type"),
+			Currency:     query.Get("currency"),
+		}
+
+		var err error
+		if filter.MinBedrooms, err = parseIntParam(r, "min_bedrooms"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if filter.MinCapacity, err = parseIntParam(r, "min_capacity"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rooms, err := roomService.ListRooms(filter)
 		if err != nil {
+			if errors.Is(err, service.ErrInvalidRoom) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 			handleError(w, "failed to fetch rooms", http.StatusInternalServerError)
 			return
 		}
 
-		if len(rooms) == 0 {
-			sendJSONResponse(w, map[string][]string{"rooms": {}})
+		sendJSONResponse(w, rooms)
+	}
+}
+
+// HandleGetRoom creates a handler for retrieving a room record.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the room endpoint
+func HandleGetRoom(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		room, err := roomService.GetRoom(mux.Vars(r)["roomId"])
+		if err != nil {
+			if errors.Is(err, service.ErrRoomNotFound) {
+				handleError(w, "room not found", http.StatusNotFound)
+				return
+			}
+			handleError(w, "failed to fetch room", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, room)
+	}
+}
+
+// HandleCreateRoom creates a handler for storing a new room record.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the room creation endpoint
+func HandleCreateRoom(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input models.Room
+		if err := decodeJSONBody(w, r, &input); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		room, err := roomService.CreateRoom(input)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidRoom):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrRoomExists):
+				handleError(w, err.Error(), http.StatusConflict)
+			default:
+				handleError(w, "failed to create room", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		sendJSONResponse(w, room)
+	}
+}
+
+// HandleUpdateRoom creates a handler for replacing the attributes of a room record.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the room update endpoint
+func HandleUpdateRoom(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var attributes models.RoomAttributes
+		if err := decodeJSONBody(w, r, &attributes); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		room, err := roomService.UpdateRoom(mux.Vars(r)["roomId"], attributes)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidRoom):
+				handleError(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, service.ErrRoomNotFound):
+				handleError(w, "room not found", http.StatusNotFound)
+			default:
+				handleError(w, "failed to update room", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		sendJSONResponse(w, room)
+	}
+}
+
+// HandleDeleteRoom creates a handler for removing a room record together with
+// its calendar, booking history and reservations.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the room deletion endpoint
+func HandleDeleteRoom(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := roomService.DeleteRoom(mux.Vars(r)["roomId"]); err != nil {
+			if errors.Is(err, service.ErrRoomNotFound) {
+				handleError(w, "room not found", http.StatusNotFound)
+				return
+			}
+			handleError(w, "failed to delete room", http.StatusInternalServerError)
 			return
 		}
 
-		sendJSONResponse(w, map[string][]string{"rooms": rooms})
+		w.WriteHeader(http.StatusNoContent)
 	}
 }
 
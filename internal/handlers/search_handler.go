@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/service"
+	"errors"
+	"net/http"
+)
+
+// HandleSearchRooms creates a handler for searching rooms by attributes and
+// metrics. Supports the room list filters, from and to for the metrics window,
+// min_occupancy, max_occupancy, min_rate and max_rate, check_in and
+// check_out, convert_to, sort, order, limit and cursor.
+// Parameters:
+//   - roomService *service.RoomService: Service for room operations
+//
+// Returns:
+//   - http.HandlerFunc: Handler function for the room search endpoint
+func HandleSearchRooms(roomService *service.RoomService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		opts := service.RoomSearchOptions{
+			RoomFilter: models.RoomFilter{
+				City:         query.Get("city"),
+				Neighborhood: query.Get("neighborhood"),
+				PropertyType: query.Get("property_type"),
+				Currency:     query.Get("currency"),
+			},
+			Currency: query.Get("convert_to"),
+			Sort:     query.Get("sort"),
+			Cursor:   query.Get("cursor"),
+		}
+
+		switch query.Get("order") {
+		case "", "asc":
+		case "desc":
+			opts.Descending = true
+		default:
+			handleError(w, "order must be asc or desc", http.StatusBadRequest)
+			return
+		}
+
+		var err error
+		if opts.MinBedrooms, err = parseIntParam(r, "min_bedrooms"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MinCapacity, err = parseIntParam(r, "min_capacity"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MinOccupancy, err = parseOptionalFloatParam(r, "min_occupancy"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MaxOccupancy, err = parseOptionalFloatParam(r, "max_occupancy"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MinRate, err = parseOptionalFloatParam(r, "min_rate"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.MaxRate, err = parseOptionalFloatParam(r, "max_rate"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.From, err = parseDateParam(r, "from"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.To, err = parseDateParam(r, "to"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.CheckIn, err = parseDateParam(r, "check_in"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.CheckOut, err = parseDateParam(r, "check_out"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.Limit, err = parseIntParam(r, "limit"); err != nil {
+			handleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := roomService.SearchRooms(opts)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWindow) || errors.Is(err, service.ErrInvalidOptions) ||
+				errors.Is(err, service.ErrInvalidRoom) || errors.Is(err, service.ErrMissingExchangeRate) {
+				handleError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			handleError(w, "failed to search rooms", http.StatusInternalServerError)
+			return
+		}
+
+		sendJSONResponse(w, result)
+	}
+}
@@ -0,0 +1,207 @@
+package importer
+
+import (
+	"airbnb-analytics/internal/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumns lists the expected CSV columns in their default order
+var csvColumns = []string{"room_id", "date", "is_booked", "rate"}
+
+// ParseCSV reads booking calendar rows in the form room_id,date,is_booked,rate.
+// A header row is optional; when present, columns may appear in any order.
+// Rows failing validation are reported individually and left out of the result.
+// Parameters:
+//   - reader io.Reader: Source of the CSV data
+//
+// Returns:
+//   - []models.RoomBooking: Valid bookings in input order
+//   - []models.ImportRowError: Validation errors of rejected rows
+//   - error: Error if the input cannot be read as CSV at all
+func ParseCSV(reader io.Reader) ([]models.RoomBooking, []models.ImportRowError, error) {
+	return parseRecords(reader, csvColumns, parseRow, func(booking models.RoomBooking) (string, string) {
+		return booking.RoomID + "|" + booking.Date, fmt.Sprintf("room %s on %s", booking.RoomID, booking.Date)
+	})
+}
+
+// parseRecords reads CSV rows whose columns are given by an optional header,
+// converting each with parse. Rows failing validation or repeating the key of
+// an earlier row are reported individually and left out of the result.
+// Parameters:
+//   - reader io.Reader: Source of the CSV data
+//   - names []string: Expected columns in their default order, the first of which every header contains
+//   - parse func([]string, map[string]int) (T, error): Converts a record given column positions keyed by name
+//   - key func(T) (string, string): Returns the key identifying a value and its description for duplicate errors
+//
+// Returns:
+//   - []T: Valid values in input order
+//   - []models.ImportRowError: Validation errors of rejected rows
+//   - error: Error if the input cannot be read as CSV at all
+func parseRecords[T any](
+	reader io.Reader,
+	names []string,
+	parse func(record []string, columns map[string]int) (T, error),
+	key func(value T) (string, string),
+) ([]T, []models.ImportRowError, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	columns := make(map[string]int, len(names))
+	for i, name := range names {
+		columns[name] = i
+	}
+	seen := make(map[string]int)
+
+	var values []T
+	var rowErrors []models.ImportRowError
+	for first := true; ; first = false {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, models.ImportRowError{Line: parseErr.Line, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("error reading CSV: %w", err)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		if first && isHeader(record, names[0]) {
+			header, err := headerColumns(record, names)
+			if err != nil {
+				return nil, nil, err
+			}
+			columns = header
+			continue
+		}
+
+		value, err := parse(record, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		id, description := key(value)
+		if previous, ok := seen[id]; ok {
+			rowErrors = append(rowErrors, models.ImportRowError{
+				Line:  line,
+				Error: fmt.Sprintf("duplicate of line %d for %s", previous, description),
+			})
+			continue
+		}
+		seen[id] = line
+
+		values = append(values, value)
+	}
+
+	return values, rowErrors, nil
+}
+
+// isHeader reports whether a record looks like a header row.
+// Parameters:
+//   - record []string: First CSV record
+//   - column string: Name of a column every header contains
+//
+// Returns:
+//   - bool: True if the record contains the column name
+func isHeader(record []string, column string) bool {
+	for _, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), column) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerColumns maps column names to their positions in a header row.
+// Parameters:
+//   - record []string: Header row
+//   - required []string: Columns the header must contain
+//
+// Returns:
+//   - map[string]int: Column positions keyed by column name
+//   - error: Error if a required column is missing
+func headerColumns(record []string, required []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, field := range record {
+		columns[strings.ToLower(strings.TrimSpace(field))] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", name)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseRow validates a single CSV record and converts it to a booking.
+// Parameters:
+//   - record []string: CSV fields
+//   - columns map[string]int: Column positions keyed by column name
+//
+// Returns:
+//   - models.RoomBooking: Parsed booking
+//   - error: Validation error describing the first invalid field
+func parseRow(record []string, columns map[string]int) (models.RoomBooking, error) {
+	field := func(name string) string {
+		if i := columns[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	for _, name := range csvColumns {
+		if columns[name] >= len(record) {
+			return models.RoomBooking{}, fmt.Errorf("expected %d fields, got %d", len(csvColumns), len(record))
+		}
+	}
+
+	roomID := field("room_id")
+	if roomID == "" {
+		return models.RoomBooking{}, fmt.Errorf("room_id is required")
+	}
+	if len(roomID) > models.MaxRoomIDLength {
+		return models.RoomBooking{}, fmt.Errorf("room_id must be at most %d characters", models.MaxRoomIDLength)
+	}
+
+	date, err := time.Parse("2006-01-02", field("date"))
+	if err != nil {
+		return models.RoomBooking{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", field("date"))
+	}
+
+	isBooked, err := strconv.ParseBool(field("is_booked"))
+	if err != nil {
+		return models.RoomBooking{}, fmt.Errorf("invalid is_booked value %q", field("is_booked"))
+	}
+
+	rate, err := strconv.ParseFloat(field("rate"), 64)
+	if err != nil || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return models.RoomBooking{}, fmt.Errorf("invalid rate %q", field("rate"))
+	}
+	if rate < 0 || rate > models.MaxRate {
+		return models.RoomBooking{}, fmt.Errorf("rate must be between 0 and %.2f", models.MaxRate)
+	}
+
+	return models.RoomBooking{
+		RoomID: roomID,
+		RoomData: models.RoomData{
+			Date:     date.Format("2006-01-02"),
+			IsBooked: isBooked,
+			Rate:     rate,
+		},
+	}, nil
+}
@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fxColumns lists the expected exchange rate CSV columns in their default order
+var fxColumns = []string{"currency", "date", "rate"}
+
+// ParseExchangeRatesCSV reads exchange rate rows in the form currency,date,rate,
+// where rate is the number of currency units per one models.BaseCurrency.
+// A header row is optional; when present, columns may appear in any order.
+// Rows failing validation are reported individually and left out of the result.
+// Parameters:
+//   - reader io.Reader: Source of the CSV data
+//
+// Returns:
+//   - []models.ExchangeRate: Valid exchange rates in input order
+//   - []models.ImportRowError: Validation errors of rejected rows
+//   - error: Error if the input cannot be read as CSV at all
+func ParseExchangeRatesCSV(reader io.Reader) ([]models.ExchangeRate, []models.ImportRowError, error) {
+	return parseRecords(reader, fxColumns, parseExchangeRateRow, func(rate models.ExchangeRate) (string, string) {
+		return rate.Currency + "|" + rate.Date, fmt.Sprintf("%s on %s", rate.Currency, rate.Date)
+	})
+}
+
+// parseExchangeRateRow validates a single CSV record and converts it to an exchange rate.
+// Parameters:
+//   - record []string: CSV fields
+//   - columns map[string]int: Column positions keyed by column name
+//
+// Returns:
+//   - models.ExchangeRate: Parsed exchange rate
+//   - error: Validation error describing the first invalid field
+func parseExchangeRateRow(record []string, columns map[string]int) (models.ExchangeRate, error) {
+	for _, name := range fxColumns {
+		if columns[name] >= len(record) {
+			return models.ExchangeRate{}, fmt.Errorf("expected %d fields, got %d", len(fxColumns), len(record))
+		}
+	}
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	currency := strings.ToUpper(field("currency"))
+	if !models.ValidCurrency(currency) {
+		return models.ExchangeRate{}, fmt.Errorf("invalid currency %q, expected a three-letter ISO 4217 code", field("currency"))
+	}
+	if currency == models.BaseCurrency {
+		return models.ExchangeRate{}, fmt.Errorf("rates are quoted against %s and can't be given for it", models.BaseCurrency)
+	}
+
+	date, err := time.Parse("2006-01-02", field("date"))
+	if err != nil {
+		return models.ExchangeRate{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", field("date"))
+	}
+
+	rate, err := strconv.ParseFloat(field("rate"), 64)
+	if err != nil || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return models.ExchangeRate{}, fmt.Errorf("invalid rate %q", field("rate"))
+	}
+	if rate <= 0 || rate > models.MaxExchangeRate {
+		return models.ExchangeRate{}, fmt.Errorf("rate must be greater than 0 and at most %d", models.MaxExchangeRate)
+	}
+
+	return models.ExchangeRate{
+		Currency: currency,
+		Date:     date.Format("2006-01-02"),
+		Rate:     rate,
+	}, nil
+}
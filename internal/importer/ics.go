@@ -0,0 +1,252 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event represents a booked stay parsed from an iCalendar VEVENT.
+// Start and End are calendar dates at midnight UTC; End is exclusive,
+// matching the iCalendar convention for all-day events.
+type Event struct {
+	// UID uniquely identifies the event within the feed
+	UID string
+	// Summary is the event title, e.g. "Reserved"
+	Summary string
+	// Start is the first booked night
+	Start time.Time
+	// End is the checkout day, not booked itself
+	End time.Time
+}
+
+// maxEventNights limits the nights a single event may span, matching the
+// longest reservation the service accepts
+const maxEventNights = 366
+
+// durationPattern matches the day and week parts of an iCalendar DURATION value
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?`)
+
+// ParseICS reads VEVENTs from an iCalendar feed.
+// Cancelled events are ignored. Date-time values are converted to the
+// calendar date they fall on in their own time zone.
+// Parameters:
+//   - reader io.Reader: Source of the iCalendar data
+//
+// Returns:
+//   - []Event: Parsed events in feed order
+//   - error: Any error encountered while reading or parsing the feed
+func ParseICS(reader io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var duration string
+	var cancelled bool
+	for i, line := range lines {
+		name, params, value := splitContentLine(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			duration = ""
+			cancelled = false
+
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("event %q has no DTSTART", current.UID)
+			}
+			if current.End.IsZero() {
+				current.End = current.Start.AddDate(0, 0, 1)
+				if duration != "" {
+					end, err := applyDuration(current.Start, duration)
+					if err != nil {
+						return nil, fmt.Errorf("event %q: %v", current.UID, err)
+					}
+					current.End = end
+				}
+			}
+			if !current.End.After(current.Start) {
+				// Same-day stays still occupy the start night
+				current.End = current.Start.AddDate(0, 0, 1)
+			}
+			if current.End.After(current.Start.AddDate(0, 0, maxEventNights)) {
+				return nil, fmt.Errorf("event %q spans more than %d nights", current.UID, maxEventNights)
+			}
+			if !cancelled {
+				events = append(events, *current)
+			}
+			current = nil
+
+		case current == nil:
+			// Properties outside of events are not needed
+
+		case name == "UID":
+			current.UID = value
+
+		case name == "SUMMARY":
+			current.Summary = value
+
+		case name == "STATUS":
+			cancelled = strings.EqualFold(value, "CANCELLED")
+
+		case name == "DURATION":
+			duration = value
+
+		case name == "DTSTART" || name == "DTEND":
+			date, err := parseICSDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %v", i+1, name, err)
+			}
+			if name == "DTSTART" {
+				current.Start = date
+			} else {
+				current.End = date
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// BookedDates expands events into the set of booked nights within a date range.
+// Nights outside the range are not expanded.
+// Parameters:
+//   - events []Event: Parsed events
+//   - from time.Time: First day of the range
+//   - to time.Time: Last day of the range (inclusive)
+//
+// Returns:
+//   - map[string]bool: Booked dates in "YYYY-MM-DD" format
+func BookedDates(events []Event, from, to time.Time) map[string]bool {
+	booked := make(map[string]bool)
+	for _, event := range events {
+		day := event.Start
+		if day.Before(from) {
+			day = from
+		}
+		for ; day.Before(event.End) && !day.After(to); day = day.AddDate(0, 0, 1) {
+			booked[day.Format("2006-01-02")] = true
+		}
+	}
+	return booked
+}
+
+// unfoldLines reads content lines, joining folded continuation lines.
+// Parameters:
+//   - reader io.Reader: Source of the iCalendar data
+//
+// Returns:
+//   - []string: Unfolded content lines
+//   - error: Any error encountered while reading
+func unfoldLines(reader io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading iCalendar data: %v", err)
+	}
+
+	return lines, nil
+}
+
+// splitContentLine splits "NAME;PARAM=VALUE:value" into its parts.
+// Parameters:
+//   - line string: Unfolded content line
+//
+// Returns:
+//   - string: Upper-cased property name
+//   - map[string]string: Property parameters keyed by upper-cased name
+//   - string: Property value
+func splitContentLine(line string) (string, map[string]string, string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+
+	params := make(map[string]string)
+	for _, part := range parts[1:] {
+		key, val, _ := strings.Cut(part, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(value)
+}
+
+// parseICSDate parses a DATE or DATE-TIME value into a calendar date.
+// Parameters:
+//   - value string: Property value, e.g. "20250101" or "20250101T150000Z"
+//   - params map[string]string: Property parameters, TZID is honoured
+//
+// Returns:
+//   - time.Time: Calendar date at midnight UTC
+//   - error: Error if the value cannot be parsed
+func parseICSDate(value string, params map[string]string) (time.Time, error) {
+	if len(value) == 8 {
+		return time.Parse("20060102", value)
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		location = loc
+	}
+
+	layout := "20060102T150405"
+	if strings.HasSuffix(value, "Z") {
+		layout += "Z"
+	}
+	t, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// applyDuration adds a day or week based iCalendar DURATION to a date.
+// Parameters:
+//   - start time.Time: Event start date
+//   - value string: DURATION value, e.g. "P3D" or "P1W"
+//
+// Returns:
+//   - time.Time: Exclusive end date
+//   - error: Error if the duration is not expressed in whole days or weeks
+func applyDuration(start time.Time, value string) (time.Time, error) {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("unsupported DURATION %q", value)
+	}
+
+	weeks, _ := strconv.Atoi(match[1])
+	days, _ := strconv.Atoi(match[2])
+	if weeks == 0 && days == 0 {
+		// Sub-day durations still occupy the start night
+		days = 1
+	}
+
+	return start.AddDate(0, 0, weeks*7+days), nil
+}
@@ -0,0 +1,318 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// files holds the migration scripts, one directory per SQL dialect.
+// Each migration consists of NNNN_name.up.sql and NNNN_name.down.sql.
+//
+//go:embed sql
+var files embed.FS
+
+// filePattern matches migration file names such as 0001_create_room_bookings.up.sql
+var filePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// placeholderPattern matches PostgreSQL style positional parameters ($1, $2, ...)
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// Migration represents a single versioned schema change.
+type Migration struct {
+	// Version orders migrations and identifies them in schema_migrations
+	Version int
+	// Name describes the migration
+	Name string
+	// Up contains the SQL applying the migration
+	Up string
+	// Down contains the SQL reverting the migration
+	Down string
+}
+
+// Status reports whether a migration has been applied.
+type Status struct {
+	Migration
+	// AppliedAt is the time the migration was applied, nil if pending
+	AppliedAt *time.Time
+}
+
+// Migrator applies and reverts migrations against a database.
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+// New creates a migrator for the given database and driver.
+// Parameters:
+//   - db *sql.DB: Open database connection
+//   - driver string: SQL dialect, "postgres" or "sqlite"
+//
+// Returns:
+//   - *Migrator: Migrator loaded with the dialect's migrations
+//   - error: Any error encountered while loading migration files
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	if driver == "" {
+		driver = "postgres"
+	}
+
+	migrations, err := load(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		driver:     driver,
+		migrations: migrations,
+	}, nil
+}
+
+// load reads and validates the embedded migrations for a dialect.
+// Parameters:
+//   - driver string: SQL dialect directory to read
+//
+// Returns:
+//   - []Migration: Migrations sorted by version
+//   - error: Any error encountered while reading or validating files
+func load(driver string) ([]Migration, error) {
+	dir := path.Join("sql", driver)
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for driver %s", driver)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := filePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := files.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %v", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("conflicting names for migration %d", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d is missing its up or down script", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ensureTable creates the schema_migrations tracking table if it doesn't exist.
+// Returns:
+//   - error: Any error encountered while creating the table
+func (m *Migrator) ensureTable() error {
+	query := `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            name VARCHAR(255) NOT NULL,
+            applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )
+    `
+	if _, err := m.db.Exec(query); err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %v", err)
+	}
+	return nil
+}
+
+// applied returns the applied migration versions with their timestamps.
+// Returns:
+//   - map[int]time.Time: Applied versions mapped to the time they were applied
+//   - error: Any error encountered while querying
+func (m *Migrator) applied() (versions map[int]time.Time, err error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying schema_migrations: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	versions = make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("error scanning migration version: %v", err)
+		}
+		versions[version] = appliedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating migration versions: %v", err)
+	}
+
+	return versions, nil
+}
+
+// Up applies all pending migrations in version order.
+// Each migration runs in its own transaction together with its tracking row.
+// Returns:
+//   - []Migration: Migrations applied by this call
+//   - error: Any error encountered; migrations applied before the failure remain applied
+func (m *Migrator) Up() ([]Migration, error) {
+	versions, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := versions[migration.Version]; ok {
+			continue
+		}
+
+		insert := m.rebind(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`)
+		if err := m.inTx(migration.Up, insert, migration.Version, migration.Name); err != nil {
+			return done, fmt.Errorf("error applying migration %04d_%s: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down reverts the most recently applied migrations.
+// Parameters:
+//   - steps int: Number of migrations to revert
+//
+// Returns:
+//   - []Migration: Migrations reverted by this call, newest first
+//   - error: Any error encountered; migrations reverted before the failure remain reverted
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	versions, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := versions[migration.Version]; !ok {
+			continue
+		}
+
+		remove := m.rebind(`DELETE FROM schema_migrations WHERE version = $1`)
+		if err := m.inTx(migration.Down, remove, migration.Version); err != nil {
+			return done, fmt.Errorf("error reverting migration %04d_%s: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Status lists all known migrations and whether they have been applied.
+// Returns:
+//   - []Status: Migrations in version order with their application time
+//   - error: Any error encountered while querying
+func (m *Migrator) Status() ([]Status, error) {
+	versions, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// inTx executes a migration script followed by a bookkeeping statement in one transaction.
+// Parameters:
+//   - script string: Migration SQL, may contain several statements
+//   - bookkeeping string: Statement updating schema_migrations
+//   - args ...interface{}: Arguments for the bookkeeping statement
+//
+// Returns:
+//   - error: Any error encountered, in which case the transaction is rolled back
+func (m *Migrator) inTx(script, bookkeeping string, args ...interface{}) (err error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+
+	// Roll back on any error, commit otherwise
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				err = fmt.Errorf("%v (rollback failed: %v)", err, rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("error committing transaction: %v", commitErr)
+		}
+	}()
+
+	if _, err = tx.Exec(script); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// rebind rewrites $N placeholders for SQLite.
+// Parameters:
+//   - query string: Query using $N placeholders
+//
+// Returns:
+//   - string: Query suitable for the migrator's driver
+func (m *Migrator) rebind(query string) string {
+	if m.driver == "sqlite" {
+		return placeholderPattern.ReplaceAllString(query, "?$1")
+	}
+	return query
+}
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS room_bookings_history ON room_bookings;
+DROP FUNCTION IF EXISTS record_room_booking_change();
+DROP TABLE IF EXISTS room_booking_history;
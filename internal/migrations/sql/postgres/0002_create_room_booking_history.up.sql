@@ -0,0 +1,31 @@
+-- room_booking_history records every state a calendar day has had, so the
+-- booking status of a night can be reconstructed as of any point in time.
+CREATE TABLE IF NOT EXISTS room_booking_history (
+    id BIGSERIAL PRIMARY KEY,
+    room_id VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    is_booked BOOLEAN NOT NULL,
+    rate DECIMAL(10,2) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_room_booking_history_room_date ON room_booking_history(room_id, date, changed_at);
+
+CREATE OR REPLACE FUNCTION record_room_booking_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.is_booked IS DISTINCT FROM OLD.is_booked OR NEW.rate IS DISTINCT FROM OLD.rate THEN
+        INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+        VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS room_bookings_history ON room_bookings;
+CREATE TRIGGER room_bookings_history
+    AFTER INSERT OR UPDATE ON room_bookings
+    FOR EACH ROW EXECUTE PROCEDURE record_room_booking_change();
+
+-- Existing days only have their current state; record it as of their creation
+INSERT INTO room_booking_history (room_id, date, is_booked, rate, changed_at)
+SELECT room_id, date, is_booked, rate, COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM room_bookings;
@@ -0,0 +1,2 @@
+-- timezone is the IANA time zone whose calendar defines a listing's "today"
+ALTER TABLE rooms ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION record_room_booking_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.is_booked IS DISTINCT FROM OLD.is_booked OR NEW.rate IS DISTINCT FROM OLD.rate THEN
+        INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+        VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE room_bookings DROP COLUMN IF EXISTS changed_at;
+
+ALTER TABLE room_booking_history
+    ALTER COLUMN changed_at TYPE TIMESTAMP USING changed_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN changed_at SET DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,19 @@
+-- changed_at records an instant; TIMESTAMPTZ keeps it independent of the
+-- server's time zone. Existing values were written in the session time zone.
+ALTER TABLE room_booking_history
+    ALTER COLUMN changed_at TYPE TIMESTAMPTZ USING changed_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN changed_at SET DEFAULT now();
+
+-- changed_at is the application's time of the last write to a day; the
+-- history trigger records it so history follows the service clock
+ALTER TABLE room_bookings ADD COLUMN IF NOT EXISTS changed_at TIMESTAMPTZ;
+
+CREATE OR REPLACE FUNCTION record_room_booking_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.is_booked IS DISTINCT FROM OLD.is_booked OR NEW.rate IS DISTINCT FROM OLD.rate THEN
+        INSERT INTO room_booking_history (room_id, date, is_booked, rate, changed_at)
+        VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate, COALESCE(NEW.changed_at, now()));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS room_bookings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    room_id VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    is_booked BOOLEAN NOT NULL,
+    rate DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(room_id, date)
+);
+CREATE INDEX IF NOT EXISTS idx_room_bookings_room_id ON room_bookings(room_id);
+CREATE INDEX IF NOT EXISTS idx_room_bookings_date ON room_bookings(date);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS room_bookings_history_update;
+DROP TRIGGER IF EXISTS room_bookings_history_insert;
+DROP TABLE IF EXISTS room_booking_history;
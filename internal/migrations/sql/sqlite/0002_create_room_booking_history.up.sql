@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS room_booking_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    room_id VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    is_booked BOOLEAN NOT NULL,
+    rate DECIMAL(10,2) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_room_booking_history_room_date ON room_booking_history(room_id, date, changed_at);
+
+CREATE TRIGGER IF NOT EXISTS room_bookings_history_insert
+AFTER INSERT ON room_bookings
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+END;
+
+CREATE TRIGGER IF NOT EXISTS room_bookings_history_update
+AFTER UPDATE OF is_booked, rate ON room_bookings
+WHEN NEW.is_booked IS NOT OLD.is_booked OR NEW.rate IS NOT OLD.rate
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+END;
+
+-- Existing days only have their current state; record it as of their creation
+INSERT INTO room_booking_history (room_id, date, is_booked, rate, changed_at)
+SELECT room_id, date, is_booked, rate, COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM room_bookings;
@@ -0,0 +1,14 @@
+-- reservations stores stays; their nights are also marked booked in room_bookings
+CREATE TABLE IF NOT EXISTS reservations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    room_id VARCHAR(50) NOT NULL,
+    check_in DATE NOT NULL,
+    check_out DATE NOT NULL,
+    booked_at TIMESTAMP NOT NULL,
+    guest_count INTEGER NOT NULL DEFAULT 1,
+    total_price DECIMAL(12,2) NOT NULL,
+    channel VARCHAR(50) NOT NULL DEFAULT 'direct',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (check_out > check_in)
+);
+CREATE INDEX IF NOT EXISTS idx_reservations_room_check_in ON reservations(room_id, check_in);
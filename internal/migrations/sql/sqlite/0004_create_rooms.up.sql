@@ -0,0 +1,19 @@
+-- rooms stores listing attributes; every room with calendar data has a record
+CREATE TABLE IF NOT EXISTS rooms (
+    room_id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    neighborhood VARCHAR(100) NOT NULL DEFAULT '',
+    property_type VARCHAR(50) NOT NULL DEFAULT '',
+    bedrooms INTEGER NOT NULL DEFAULT 0,
+    capacity INTEGER NOT NULL DEFAULT 0,
+    latitude REAL,
+    longitude REAL,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_rooms_city ON rooms(city);
+-- Existing rooms start out with default attributes
+INSERT OR IGNORE INTO rooms (room_id)
+SELECT DISTINCT room_id FROM room_bookings;
@@ -0,0 +1,9 @@
+-- exchange_rates stores daily currency values as units per one USD
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency CHAR(3) NOT NULL,
+    date DATE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (currency, date),
+    CHECK (rate > 0)
+);
@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS room_bookings_history_insert;
+CREATE TRIGGER room_bookings_history_insert
+AFTER INSERT ON room_bookings
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+END;
+
+DROP TRIGGER IF EXISTS room_bookings_history_update;
+CREATE TRIGGER room_bookings_history_update
+AFTER UPDATE OF is_booked, rate ON room_bookings
+WHEN NEW.is_booked IS NOT OLD.is_booked OR NEW.rate IS NOT OLD.rate
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate);
+END;
+
+ALTER TABLE room_bookings DROP COLUMN changed_at;
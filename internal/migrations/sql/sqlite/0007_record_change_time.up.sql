@@ -0,0 +1,20 @@
+-- changed_at is the application's time of the last write to a day; the
+-- history triggers record it so history follows the service clock
+ALTER TABLE room_bookings ADD COLUMN changed_at TIMESTAMP;
+
+DROP TRIGGER IF EXISTS room_bookings_history_insert;
+CREATE TRIGGER room_bookings_history_insert
+AFTER INSERT ON room_bookings
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate, changed_at)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate, COALESCE(NEW.changed_at, CURRENT_TIMESTAMP));
+END;
+
+DROP TRIGGER IF EXISTS room_bookings_history_update;
+CREATE TRIGGER room_bookings_history_update
+AFTER UPDATE OF is_booked, rate ON room_bookings
+WHEN NEW.is_booked IS NOT OLD.is_booked OR NEW.rate IS NOT OLD.rate
+BEGIN
+    INSERT INTO room_booking_history (room_id, date, is_booked, rate, changed_at)
+    VALUES (NEW.room_id, NEW.date, NEW.is_booked, NEW.rate, COALESCE(NEW.changed_at, CURRENT_TIMESTAMP));
+END;
@@ -1,5 +1,207 @@
 package models
 
+import (
+	"regexp"
+	"time"
+)
+
+const (
+	// MaxRoomIDLength matches the room_id VARCHAR(50) column
+	MaxRoomIDLength = 50
+	// MaxRate is the largest value that fits the rate DECIMAL(10,2) column
+	MaxRate = 99999999.99
+	// MaxReservationPrice is the largest value that fits the total_price DECIMAL(12,2) column
+	MaxReservationPrice = 9999999999.99
+	// MaxChannelLength matches the channel VARCHAR(50) column
+	MaxChannelLength = 50
+	// MaxRoomNameLength matches the rooms.name VARCHAR(255) column
+	MaxRoomNameLength = 255
+	// MaxLocationLength matches the rooms.city and rooms.neighborhood VARCHAR(100) columns
+	MaxLocationLength = 100
+	// MaxPropertyTypeLength matches the rooms.property_type VARCHAR(50) column
+	MaxPropertyTypeLength = 50
+	// MaxTimeZoneLength matches the rooms.timezone VARCHAR(64) column
+	MaxTimeZoneLength = 64
+	// DefaultCurrency is the currency of rooms that don't specify one
+	DefaultCurrency = "USD"
+	// DefaultTimeZone is the time zone of rooms that don't specify one
+	DefaultTimeZone = "UTC"
+	// BaseCurrency is the currency exchange rates are quoted against
+	BaseCurrency = "USD"
+	// MaxExchangeRate is the largest whole value that fits the exchange_rates.rate DECIMAL(18,8) column
+	MaxExchangeRate = 9999999999
+)
+
+// currencyPattern matches ISO 4217 currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ValidCurrency reports whether a code is a three-letter upper-case ISO 4217 currency code.
+// Parameters:
+//   - code string: Currency code to check
+//
+// Returns:
+//   - bool: True if the code is well formed
+func ValidCurrency(code string) bool {
+	return currencyPattern.MatchString(code)
+}
+
+// Room represents a listing and its descriptive attributes.
+type Room struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	RoomAttributes
+}
+
+// RoomAttributes represents the descriptive attributes of a listing.
+type RoomAttributes struct {
+	// Name is the listing title
+	Name string `json:"name"`
+	// City is the city the listing is located in
+	City string `json:"city"`
+	// Neighborhood is the neighborhood within the city
+	Neighborhood string `json:"neighborhood"`
+	// PropertyType describes the kind of property, e.g. "apartment"
+	PropertyType string `json:"property_type"`
+	// Bedrooms is the number of bedrooms
+	Bedrooms int `json:"bedrooms"`
+	// Capacity is the maximum number of guests
+	Capacity int `json:"capacity"`
+	// Latitude is the listing's latitude in degrees, nil if unknown
+	Latitude *float64 `json:"latitude"`
+	// Longitude is the listing's longitude in degrees, nil if unknown
+	Longitude *float64 `json:"longitude"`
+	// Currency is the ISO 4217 code of the currency rates are quoted in
+	Currency string `json:"currency"`
+	// TimeZone is the IANA name of the listing's time zone, e.g. "Asia/Tokyo";
+	// "today" and the default analysis windows follow its calendar
+	TimeZone string `json:"timezone"`
+}
+
+// RoomFilter selects rooms by their attributes. Zero values match any room;
+// text attributes are compared case-insensitively.
+type RoomFilter struct {
+	// City selects rooms in the given city
+	City string
+	// Neighborhood selects rooms in the given neighborhood
+	Neighborhood string
+	// PropertyType selects rooms of the given property type
+	PropertyType string
+	// Currency selects rooms quoting rates in the given currency
+	Currency string
+	// MinBedrooms selects rooms with at least this many bedrooms
+	MinBedrooms int
+	// MinCapacity selects rooms accommodating at least this many guests
+	MinCapacity int
+}
+
+// ExchangeRate represents the value of a currency on a single day.
+type ExchangeRate struct {
+	// Currency is the ISO 4217 code of the currency
+	Currency string `json:"currency"`
+	// Date is the day the rate applies to in "YYYY-MM-DD" format
+	Date string `json:"date"`
+	// Rate is the number of currency units per one BaseCurrency
+	Rate float64 `json:"rate"`
+}
+
+// RoomList represents the rooms matching a filter.
+type RoomList struct {
+	// Rooms contains the matching rooms ordered by room ID
+	Rooms []Room `json:"rooms"`
+}
+
+// Sort orders of a room search, each naming the metric rooms are ordered by
+const (
+	SearchSortRoomID       = "room_id"
+	SearchSortOccupancy    = "occupancy"
+	SearchSortAverageRate  = "average_rate"
+	SearchSortADR          = "adr"
+	SearchSortRevPAR       = "revpar"
+	SearchSortBookedNights = "booked_nights"
+)
+
+// RoomSearchQuery selects, orders and pages the rooms of a room search.
+// Metrics are computed over the nights with data between From and To and
+// filtered and sorted unrounded.
+type RoomSearchQuery struct {
+	RoomFilter
+	// From is the first day of the metrics window
+	From time.Time
+	// To is the last day of the metrics window
+	To time.Time
+	// CheckIn and CheckOut keep only rooms with an unbooked row for every
+	// night from CheckIn up to but excluding CheckOut, zero for any room
+	CheckIn, CheckOut time.Time
+	// Factors convert rates into a common currency before metrics are
+	// computed; rates without a factor are used as they are
+	Factors []ConversionFactor
+	// MinOccupancy and MaxOccupancy bound the occupancy percentage, nil for no bound
+	MinOccupancy, MaxOccupancy *float64
+	// MinRate and MaxRate bound the average rate, nil for no bound
+	MinRate, MaxRate *float64
+	// Sort is one of the SearchSort constants
+	Sort string
+	// Descending reverses the order of the sort metric; ties stay ordered by room ID
+	// unless rooms are sorted by room ID itself
+	Descending bool
+	// After is the position of the last room of the previous page, nil for the first page
+	After *RoomSearchPosition
+	// Limit is the maximum number of rooms to return
+	Limit int
+}
+
+// RoomSearchPosition identifies a room's place in the order of a room search.
+type RoomSearchPosition struct {
+	// Value is the room's sort metric, ignored when sorting by room ID
+	Value float64
+	// RoomID is the unique identifier of the room
+	RoomID string
+}
+
+// ConversionFactor converts a rate quoted in a currency on a day into
+// another currency by multiplication.
+type ConversionFactor struct {
+	// Currency is the ISO 4217 code the rate is quoted in
+	Currency string `json:"currency"`
+	// Date is the day of the rate in YYYY-MM-DD format
+	Date string `json:"date"`
+	// Factor is the target currency units per unit of Currency on Date
+	Factor float64 `json:"factor"`
+}
+
+// RoomSearchResult represents a page of rooms matching a search.
+type RoomSearchResult struct {
+	// From is the first day the metrics cover in YYYY-MM-DD format
+	From string `json:"from"`
+	// To is the last day the metrics cover in YYYY-MM-DD format
+	To string `json:"to"`
+	// Currency is the currency rates were converted to, omitted if rates are
+	// reported in each room's own currency
+	Currency string `json:"currency,omitempty"`
+	// Rooms contains the matching rooms of this page in the requested order
+	Rooms []RoomSearchMatch `json:"rooms"`
+	// NextCursor fetches the next page, omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// RoomSearchMatch represents a room matching a search together with the
+// metrics it was filtered and sorted by.
+type RoomSearchMatch struct {
+	Room
+	// OccupancyPercentage is the share of booked nights in the metrics window
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// AverageRate is the mean rate of all nights in the metrics window
+	AverageRate float64 `json:"average_rate"`
+	// ADR is the average rate of booked nights in the metrics window
+	ADR float64 `json:"adr"`
+	// RevPAR is the revenue per night with data in the metrics window
+	RevPAR float64 `json:"revpar"`
+	// BookedNights is the number of booked nights in the metrics window
+	BookedNights int `json:"booked_nights"`
+	// Nights is the number of nights with data in the metrics window
+	Nights int `json:"nights"`
+}
+
 // RoomData represents the booking information for a single day of a room.
 // It contains date, booking status and rate information.
 type RoomData struct {
@@ -7,19 +209,98 @@ type RoomData struct {
 	Date string `json:"date"`
 	// IsBooked indicates whether the room is booked for this date
 	IsBooked bool `json:"is_booked"`
-	// Rate represents the room rate for this date in the local currency
+	// Rate represents the room rate for this date in the room's currency
 	Rate float64 `json:"rate"`
 }
 
+// RoomBooking represents the booking information for a single day of a
+// specific room. It is used when reading or writing data for several rooms.
+type RoomBooking struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	RoomData
+}
+
+// BookingChange represents a recorded state of a room's calendar day.
+// A new change is recorded whenever a day is created or its booking status
+// or rate changes.
+type BookingChange struct {
+	RoomBooking
+	// ChangedAt is the time the day took this state
+	ChangedAt time.Time `json:"changed_at"`
+}
+
 // AnalyticsResponse represents the complete analytics response for a room.
 // It includes the room identifier, occupancy data and rate analytics.
 type AnalyticsResponse struct {
 	// RoomID uniquely identifies the room
 	RoomID string `json:"room_id"`
+	// Currency is the ISO 4217 code of the currency rates are reported in
+	Currency string `json:"currency"`
+	// Window describes the date ranges the analytics were computed over
+	Window AnalysisWindow `json:"window"`
 	// MonthlyOccupancy contains occupancy data for upcoming months
 	MonthlyOccupancy []MonthlyOccupancy `json:"monthly_occupancy"`
 	// RateAnalytics contains statistical analysis of room rates
 	RateAnalytics RateAnalytics `json:"rate_analytics"`
+	// DayOfWeek contains occupancy and rate statistics per weekday over the occupancy window
+	DayOfWeek []DayOfWeekStats `json:"day_of_week"`
+	// WeekendSummary compares weekend and weekday nights over the occupancy window
+	WeekendSummary WeekendSummary `json:"weekend_summary"`
+}
+
+// NightStats represents occupancy and rate statistics for a group of nights.
+type NightStats struct {
+	// Nights is the number of nights with data in the group
+	Nights int `json:"nights"`
+	// BookedNights is the number of booked nights in the group
+	BookedNights int `json:"booked_nights"`
+	// OccupancyPercentage is the percentage of booked nights in the group
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// AverageRate is the mean rate of all nights in the group
+	AverageRate float64 `json:"average_rate"`
+}
+
+// DayOfWeekStats represents statistics for a single day of the week.
+type DayOfWeekStats struct {
+	// Day is the lower-case weekday name, e.g. "monday"
+	Day string `json:"day"`
+	NightStats
+}
+
+// WeekendSummary compares weekend nights with weekday nights.
+type WeekendSummary struct {
+	// WeekendDays lists the weekdays counted as weekend
+	WeekendDays []string `json:"weekend_days"`
+	// Weekend contains statistics for weekend nights
+	Weekend NightStats `json:"weekend"`
+	// Weekday contains statistics for all other nights
+	Weekday NightStats `json:"weekday"`
+}
+
+// AnalysisWindow describes the date ranges used to compute room analytics.
+// All dates are in "YYYY-MM-DD" format and both bounds are inclusive.
+type AnalysisWindow struct {
+	// AsOf is the date the analytics were computed as of
+	AsOf string `json:"as_of"`
+	// From is the first day of the occupancy window
+	From string `json:"from"`
+	// To is the last day of the occupancy window
+	To string `json:"to"`
+	// OccupancyMonths is the number of months covered by the occupancy window,
+	// zero when an explicit end date was requested
+	OccupancyMonths int `json:"occupancy_months,omitempty"`
+	// RateFrom is the first day of the rate window
+	RateFrom string `json:"rate_from"`
+	// RateTo is the last day of the rate window
+	RateTo string `json:"rate_to"`
+	// RateDays is the number of days covered by the rate window
+	RateDays int `json:"rate_days"`
+	// Compare is the requested comparison period, omitted without comparison
+	Compare string `json:"compare,omitempty"`
+	// TimeZone is the time zone whose calendar determined today, omitted for
+	// analytics across rooms, which use UTC
+	TimeZone string `json:"timezone,omitempty"`
 }
 
 // MonthlyOccupancy represents the occupancy statistics for a single month.
@@ -28,6 +309,62 @@ type MonthlyOccupancy struct {
 	Month string `json:"month"`
 	// OccupancyPercentage represents the percentage of days booked in this month
 	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// BookedNights is the number of booked nights in this month
+	BookedNights int `json:"booked_nights"`
+	// TotalNights is the number of nights with data in this month
+	TotalNights int `json:"total_nights"`
+	// Revenue is the sum of rates of booked nights in this month
+	Revenue float64 `json:"revenue"`
+	// ADR is the average daily rate of booked nights in this month
+	ADR float64 `json:"adr"`
+	// RevPAR is the revenue per available night in this month
+	RevPAR float64 `json:"revpar"`
+	// Comparison contains the same metrics for the comparison month, if requested
+	// and the comparison month has data
+	Comparison *MonthlyComparison `json:"comparison,omitempty"`
+}
+
+// MonthlyComparison compares a month's occupancy metrics with another month.
+type MonthlyComparison struct {
+	// Month is the comparison month in "YYYY-MM" format
+	Month string `json:"month"`
+	// OccupancyPercentage compares the occupancy percentages
+	OccupancyPercentage MetricChange `json:"occupancy_percentage"`
+	// Revenue compares the revenues
+	Revenue MetricChange `json:"revenue"`
+	// ADR compares the average daily rates
+	ADR MetricChange `json:"adr"`
+	// RevPAR compares the revenues per available night
+	RevPAR MetricChange `json:"revpar"`
+}
+
+// RateComparison compares rate analytics with another period.
+type RateComparison struct {
+	// From is the first day of the comparison period
+	From string `json:"from"`
+	// To is the last day of the comparison period
+	To string `json:"to"`
+	// AverageRate compares the mean rates
+	AverageRate MetricChange `json:"average_rate"`
+	// MedianRate compares the median rates
+	MedianRate MetricChange `json:"median_rate"`
+	// ADR compares the average daily rates
+	ADR MetricChange `json:"adr"`
+	// RevPAR compares the revenues per available night
+	RevPAR MetricChange `json:"revpar"`
+	// TotalRevenue compares the revenues
+	TotalRevenue MetricChange `json:"total_revenue"`
+}
+
+// MetricChange represents a metric's value in the comparison period and how
+// the current value differs from it.
+type MetricChange struct {
+	// Comparison is the metric's value in the comparison period
+	Comparison float64 `json:"comparison"`
+	// Change is the current value minus the comparison value
+	Change float64 `json:"change"`
+	// PercentChange is the change relative to the comparison value, null if it is zero
+	PercentChange *float64 `json:"percent_change"`
 }
 
 // RateAnalytics represents statistical analysis of room rates.
@@ -39,4 +376,542 @@ type RateAnalytics struct {
 	HighestRate float64 `json:"highest_rate"`
 	// LowestRate represents the minimum rate in the analyzed period
 	LowestRate float64 `json:"lowest_rate"`
+	// MedianRate represents the median rate in the analyzed period
+	MedianRate float64 `json:"median_rate"`
+	// Percentiles maps requested percentiles, e.g. "p90", to rates
+	Percentiles map[string]float64 `json:"percentiles"`
+	// StandardDeviation is the population standard deviation of rates
+	StandardDeviation float64 `json:"standard_deviation"`
+	// CoefficientOfVariation is the standard deviation divided by the average rate
+	CoefficientOfVariation float64 `json:"coefficient_of_variation"`
+	// ADR is the average daily rate of booked nights only
+	ADR float64 `json:"adr"`
+	// RevPAR is the realized revenue divided by the number of nights
+	RevPAR float64 `json:"revpar"`
+	// TotalRevenue is the sum of rates of booked nights
+	TotalRevenue float64 `json:"total_revenue"`
+	// PotentialRevenue is the sum of rates of all nights, booked or not
+	PotentialRevenue float64 `json:"potential_revenue"`
+	// BookedNights is the number of booked nights in the analyzed period
+	BookedNights int `json:"booked_nights"`
+	// TotalNights is the number of nights with data in the analyzed period
+	TotalNights int `json:"total_nights"`
+	// Comparison contains the same metrics for the comparison period, if requested
+	// and the comparison period has data
+	Comparison *RateComparison `json:"comparison,omitempty"`
+}
+
+// ImportReport summarizes the outcome of a bulk booking import.
+type ImportReport struct {
+	// RowsRead is the number of data rows found in the input
+	RowsRead int `json:"rows_read"`
+	// Imported is the number of rows written to storage
+	Imported int `json:"imported"`
+	// Rejected is the number of rows that failed validation
+	Rejected int `json:"rejected"`
+	// Errors lists the validation error of each rejected row
+	Errors []ImportRowError `json:"errors"`
+}
+
+// ImportRowError describes an input row that was rejected during import.
+type ImportRowError struct {
+	// Line is the 1-based line number of the row in the input
+	Line int `json:"line"`
+	// Error explains why the row was rejected
+	Error string `json:"error"`
+}
+
+// AvailabilityImportReport summarizes the ingestion of an availability feed.
+type AvailabilityImportReport struct {
+	// RoomID identifies the room the feed was applied to
+	RoomID string `json:"room_id"`
+	// From is the first day updated, in "YYYY-MM-DD" format
+	From string `json:"from"`
+	// To is the last day updated, in "YYYY-MM-DD" format
+	To string `json:"to"`
+	// Events is the number of booked stays found in the feed
+	Events int `json:"events"`
+	// BookedDays is the number of days marked as booked
+	BookedDays int `json:"booked_days"`
+	// AvailableDays is the number of days marked as available
+	AvailableDays int `json:"available_days"`
+}
+
+// CalendarUpdate represents a request to set booking status and rate for
+// calendar days. Either Days or the range From/To with IsBooked and Rate
+// must be given.
+type CalendarUpdate struct {
+	// Days lists individual days to set
+	Days []CalendarDayInput `json:"days,omitempty"`
+	// From is the first day of the range to set, in "YYYY-MM-DD" format
+	From string `json:"from,omitempty"`
+	// To is the last day of the range to set, in "YYYY-MM-DD" format
+	To string `json:"to,omitempty"`
+	// IsBooked is the booking status applied to every day of the range
+	IsBooked *bool `json:"is_booked,omitempty"`
+	// Rate is the rate applied to every day of the range
+	Rate *float64 `json:"rate,omitempty"`
+}
+
+// CalendarDayInput represents a single day to set in a CalendarUpdate.
+// All fields are required so that an omitted field can't reset a stored value.
+type CalendarDayInput struct {
+	// Date is the day to set in "YYYY-MM-DD" format
+	Date string `json:"date"`
+	// IsBooked is the new booking status
+	IsBooked *bool `json:"is_booked"`
+	// Rate is the new rate
+	Rate *float64 `json:"rate"`
+}
+
+// CalendarDayPatch represents a partial update of a single calendar day.
+// Fields left nil keep their current value.
+type CalendarDayPatch struct {
+	// IsBooked is the new booking status
+	IsBooked *bool `json:"is_booked"`
+	// Rate is the new rate
+	Rate *float64 `json:"rate"`
+}
+
+// CalendarResponse represents calendar days of a room.
+type CalendarResponse struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	// Days contains the calendar days ordered by date
+	Days []RoomData `json:"days"`
+}
+
+// Calendar day statuses reported by CalendarDay
+const (
+	// DayStatusBooked marks a day that is booked
+	DayStatusBooked = "booked"
+	// DayStatusAvailable marks a day that is not booked
+	DayStatusAvailable = "available"
+	// DayStatusUnknown marks a day without stored data
+	DayStatusUnknown = "unknown"
+)
+
+// CalendarDay represents a single day of a room's raw calendar.
+// IsBooked and Rate are null for days with unknown status.
+type CalendarDay struct {
+	// Date represents the day in "YYYY-MM-DD" format
+	Date string `json:"date"`
+	// Status is one of "booked", "available" or "unknown"
+	Status string `json:"status"`
+	// IsBooked indicates whether the room is booked for this date
+	IsBooked *bool `json:"is_booked"`
+	// Rate represents the room rate for this date in the local currency
+	Rate *float64 `json:"rate"`
+}
+
+// CalendarPage represents a page of a room's raw calendar.
+type CalendarPage struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	// From is the first day of this page
+	From string `json:"from"`
+	// To is the last day of this page
+	To string `json:"to"`
+	// NextFrom is the first day of the next page, empty on the last page
+	NextFrom string `json:"next_from,omitempty"`
+	// Days contains the calendar days of this page ordered by date
+	Days []CalendarDay `json:"days"`
+}
+
+// PortfolioAnalytics represents analytics aggregated across several rooms.
+type PortfolioAnalytics struct {
+	// Currency is the currency rates were converted to or, without
+	// conversion, the currency all rooms share; omitted without data
+	Currency string `json:"currency,omitempty"`
+	// Window describes the date ranges the analytics were computed over
+	Window AnalysisWindow `json:"window"`
+	// RoomCount is the number of rooms with data in the analyzed period
+	RoomCount int `json:"room_count"`
+	// MonthlyOccupancy contains occupancy across all rooms per month
+	MonthlyOccupancy []MonthlyOccupancy `json:"monthly_occupancy"`
+	// RateAnalytics contains statistical analysis of rates across all rooms
+	RateAnalytics RateAnalytics `json:"rate_analytics"`
+	// Rooms ranks the individual rooms, ordered by occupancy
+	Rooms []PortfolioRoom `json:"rooms"`
+}
+
+// PortfolioRoom represents a single room's figures within a portfolio.
+type PortfolioRoom struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	// OccupancyPercentage is the share of booked days in the occupancy window
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// AverageRate is the mean rate in the rate window
+	AverageRate float64 `json:"average_rate"`
+	// OccupancyRank is the room's position by occupancy, 1 being the highest
+	OccupancyRank int `json:"occupancy_rank"`
+	// RateRank is the room's position by average rate, 1 being the highest
+	RateRank int `json:"rate_rank"`
+	// OrphanGaps is the number of orphan gaps in the occupancy window
+	OrphanGaps int `json:"orphan_gaps"`
+	// OrphanNights is the number of nights in orphan gaps in the occupancy window
+	OrphanNights int `json:"orphan_nights"`
+}
+
+// OccupancyForecast represents projected monthly occupancy for a room.
+type OccupancyForecast struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// AsOf is the date the forecast was made on in YYYY-MM-DD format
+	AsOf string `json:"as_of"`
+	// Method is the forecasting method used
+	Method string `json:"method"`
+	// Alpha is the smoothing factor, only set for exponential smoothing
+	Alpha *float64 `json:"alpha,omitempty"`
+	// ConfidenceLevel is the confidence level of the intervals in percent
+	ConfidenceLevel float64 `json:"confidence_level"`
+	// HistoryFrom is the first month of history used in YYYY-MM format
+	HistoryFrom string `json:"history_from"`
+	// HistoryTo is the last month of history used in YYYY-MM format
+	HistoryTo string `json:"history_to"`
+	// Forecast contains the projected months starting with the AsOf month
+	Forecast []ForecastPoint `json:"forecast"`
+	// Backtest contains the evaluation against known history, if requested
+	Backtest *ForecastBacktest `json:"backtest,omitempty"`
+}
+
+// ForecastPoint represents the projected occupancy of a single month.
+type ForecastPoint struct {
+	// Month is the forecast month in YYYY-MM format
+	Month string `json:"month"`
+	// OccupancyPercentage is the point estimate
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// LowerBound is the lower end of the confidence interval
+	LowerBound float64 `json:"lower_bound"`
+	// UpperBound is the upper end of the confidence interval
+	UpperBound float64 `json:"upper_bound"`
+	// Actual is the observed occupancy, only set in backtests
+	Actual *float64 `json:"actual_occupancy_percentage,omitempty"`
+}
+
+// ForecastBacktest represents forecast errors over held out history.
+type ForecastBacktest struct {
+	// From is the first held out month in YYYY-MM format
+	From string `json:"from"`
+	// To is the last held out month in YYYY-MM format
+	To string `json:"to"`
+	// EvaluatedMonths is the number of held out months with data
+	EvaluatedMonths int `json:"evaluated_months"`
+	// MAE is the mean absolute error in percentage points
+	MAE float64 `json:"mae"`
+	// MAPE is the mean absolute percentage error, omitted if all actuals are zero
+	MAPE *float64 `json:"mape,omitempty"`
+	// RMSE is the root mean squared error in percentage points
+	RMSE float64 `json:"rmse"`
+	// Points contains the held out forecasts with their actual values
+	Points []ForecastPoint `json:"points"`
+}
+
+// PriceSuggestions represents suggested rates for the unbooked days of a room.
+type PriceSuggestions struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// AsOf is the date the suggestions were made on in YYYY-MM-DD format
+	AsOf string `json:"as_of"`
+	// From is the first priced day in YYYY-MM-DD format
+	From string `json:"from"`
+	// To is the last priced day in YYYY-MM-DD format
+	To string `json:"to"`
+	// HistoryFrom is the first historical day used in YYYY-MM-DD format
+	HistoryFrom string `json:"history_from"`
+	// HistoryTo is the last historical day used in YYYY-MM-DD format
+	HistoryTo string `json:"history_to"`
+	// BaseRate is the median historical rate all suggestions start from
+	BaseRate float64 `json:"base_rate"`
+	// RateLevels contains the historical occupancy achieved per rate level
+	RateLevels []RateLevel `json:"rate_levels"`
+	// Days contains a suggestion per unbooked day in the range
+	Days []PriceSuggestion `json:"days"`
+}
+
+// RateLevel represents the historical performance of a rate range.
+type RateLevel struct {
+	// MinRate is the lowest rate of the level
+	MinRate float64 `json:"min_rate"`
+	// MaxRate is the highest rate observed in the level
+	MaxRate float64 `json:"max_rate"`
+	NightStats
+	// ExpectedRevenue is the average rate multiplied by the occupancy of the level
+	ExpectedRevenue float64 `json:"expected_revenue"`
+}
+
+// PriceSuggestion represents the suggested rate of a single day.
+type PriceSuggestion struct {
+	// Date is the day in YYYY-MM-DD format
+	Date string `json:"date"`
+	// CurrentRate is the rate currently stored for the day
+	CurrentRate float64 `json:"current_rate"`
+	// SuggestedRate is the product of all factors
+	SuggestedRate float64 `json:"suggested_rate"`
+	// DaysUntilArrival is the number of days between as_of and the day
+	DaysUntilArrival int `json:"days_until_arrival"`
+	// Factors contains the reasoning behind the suggestion
+	Factors PriceFactors `json:"factors"`
+}
+
+// PriceFactors represents the components of a suggested rate.
+// The suggested rate is the base rate multiplied by all other factors.
+type PriceFactors struct {
+	// BaseRate is the median historical rate
+	BaseRate float64 `json:"base_rate"`
+	// PriceLevel moves the base rate towards the rate level with the highest expected revenue
+	PriceLevel float64 `json:"price_level"`
+	// DayOfWeek reflects the historical demand on the day's weekday
+	DayOfWeek float64 `json:"day_of_week"`
+	// Seasonality reflects the historical demand in the day's month
+	Seasonality float64 `json:"seasonality"`
+	// LeadTime reflects the number of days left to sell the night
+	LeadTime float64 `json:"lead_time"`
+}
+
+// BookingPace represents how bookings for a month built up over time.
+type BookingPace struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// Month is the target month in YYYY-MM format
+	Month string `json:"month"`
+	// LastYearMonth is the comparison month one year earlier in YYYY-MM format
+	LastYearMonth string `json:"last_year_month"`
+	// Points contains the bookings on the books at each lead time
+	Points []PacePoint `json:"points"`
+}
+
+// PacePoint compares the bookings on the books at a lead time with last year.
+type PacePoint struct {
+	// DaysOut is the number of days before the first night of the month
+	DaysOut int `json:"days_out"`
+	// Current contains the target month's bookings, omitted if the cutoff is in the future
+	Current *PaceSnapshot `json:"current,omitempty"`
+	// LastYear contains last year's bookings, omitted without recorded history
+	LastYear *PaceSnapshot `json:"last_year,omitempty"`
+	// OccupancyChange is the difference in occupancy percentage points to last year
+	OccupancyChange *float64 `json:"occupancy_change,omitempty"`
+	// BookedNightsChange is the difference in booked nights to last year
+	BookedNightsChange *int `json:"booked_nights_change,omitempty"`
+}
+
+// PaceSnapshot represents the bookings of a month as of a cutoff day.
+type PaceSnapshot struct {
+	// Cutoff is the last day whose changes are included in YYYY-MM-DD format
+	Cutoff string `json:"cutoff"`
+	// BookedNights is the number of nights booked at the cutoff
+	BookedNights int `json:"booked_nights"`
+	// TotalNights is the number of nights in the month
+	TotalNights int `json:"total_nights"`
+	// OccupancyPercentage is the on-the-books occupancy of the month
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// Revenue is the sum of rates of the booked nights
+	Revenue float64 `json:"revenue"`
+	// ADR is the revenue divided by booked nights
+	ADR float64 `json:"adr"`
+}
+
+// Reservation represents a stay in a room. Its nights, from check-in up to
+// but excluding check-out, are booked in the daily calendar.
+type Reservation struct {
+	// ID uniquely identifies the reservation
+	ID int64 `json:"id"`
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// CheckIn is the first night in YYYY-MM-DD format
+	CheckIn string `json:"check_in"`
+	// CheckOut is the departure day in YYYY-MM-DD format, not booked itself
+	CheckOut string `json:"check_out"`
+	// BookedAt is the time the reservation was made
+	BookedAt time.Time `json:"booked_at"`
+	// GuestCount is the number of guests
+	GuestCount int `json:"guest_count"`
+	// TotalPrice is the price of the whole stay
+	TotalPrice float64 `json:"total_price"`
+	// Channel is the source of the reservation, e.g. "airbnb" or "direct"
+	Channel string `json:"channel"`
+}
+
+// ReservationInput represents a request to create a reservation.
+type ReservationInput struct {
+	// CheckIn is the first night in YYYY-MM-DD format
+	CheckIn string `json:"check_in"`
+	// CheckOut is the departure day in YYYY-MM-DD format
+	CheckOut string `json:"check_out"`
+	// BookedAt is the time the reservation was made, defaults to now
+	BookedAt *time.Time `json:"booked_at"`
+	// GuestCount is the number of guests, defaults to 1
+	GuestCount int `json:"guest_count"`
+	// TotalPrice is the price of the whole stay, spread evenly over its nights
+	// with the cents left over charged on the last night
+	TotalPrice float64 `json:"total_price"`
+	// Channel is the source of the reservation, defaults to "direct"
+	Channel string `json:"channel"`
+}
+
+// ReservationList represents the reservations of a room checking in within a range.
+type ReservationList struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// From is the first check-in day included in YYYY-MM-DD format
+	From string `json:"from"`
+	// To is the last check-in day included in YYYY-MM-DD format
+	To string `json:"to"`
+	// Reservations contains the reservations ordered by check-in
+	Reservations []Reservation `json:"reservations"`
+}
+
+// ReservationAnalytics represents stay statistics of a room's reservations.
+type ReservationAnalytics struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// From is the first check-in day included in YYYY-MM-DD format
+	From string `json:"from"`
+	// To is the last check-in day included in YYYY-MM-DD format
+	To string `json:"to"`
+	// Reservations is the number of reservations analyzed
+	Reservations int `json:"reservations"`
+	// Nights is the total number of reserved nights
+	Nights int `json:"nights"`
+	// AverageLengthOfStay is the mean number of nights per reservation
+	AverageLengthOfStay float64 `json:"average_length_of_stay"`
+	// MedianLengthOfStay is the median number of nights per reservation
+	MedianLengthOfStay float64 `json:"median_length_of_stay"`
+	// AverageLeadTime is the mean number of days between booking and check-in
+	AverageLeadTime float64 `json:"average_lead_time"`
+	// MedianLeadTime is the median number of days between booking and check-in
+	MedianLeadTime float64 `json:"median_lead_time"`
+	// AverageGuestCount is the mean number of guests per reservation
+	AverageGuestCount float64 `json:"average_guest_count"`
+	// LengthOfStay is the distribution of reservations by number of nights
+	LengthOfStay []DistributionBucket `json:"length_of_stay"`
+	// LeadTime is the distribution of reservations by days between booking and check-in
+	LeadTime []DistributionBucket `json:"lead_time"`
+	// CheckInDayOfWeek is the distribution of reservations by weekday of check-in
+	CheckInDayOfWeek []DistributionBucket `json:"check_in_day_of_week"`
+	// Channels is the distribution of reservations by channel
+	Channels []DistributionBucket `json:"channels"`
+}
+
+// DistributionBucket represents the share of reservations in a category.
+type DistributionBucket struct {
+	// Label names the category, e.g. "2-3" nights or "friday"
+	Label string `json:"label"`
+	// Count is the number of reservations in the category
+	Count int `json:"count"`
+	// Percentage is the share of reservations in the category
+	Percentage float64 `json:"percentage"`
+}
+
+// GapAnalysis represents the booked and available streaks of a room's calendar.
+type GapAnalysis struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// From is the first analyzed day in YYYY-MM-DD format
+	From string `json:"from"`
+	// To is the last analyzed day in YYYY-MM-DD format
+	To string `json:"to"`
+	// MaxGap is the longest available streak between bookings counted as an orphan gap
+	MaxGap int `json:"max_gap"`
+	// OrphanGapCount is the number of orphan gaps
+	OrphanGapCount int `json:"orphan_gap_count"`
+	// OrphanNights is the number of nights in orphan gaps
+	OrphanNights int `json:"orphan_nights"`
+	// OrphanGaps contains the short available streaks enclosed by bookings
+	OrphanGaps []Streak `json:"orphan_gaps"`
+	// LongestAvailable is the longest available streak, omitted if there is none
+	LongestAvailable *Streak `json:"longest_available,omitempty"`
+	// LongestBooked is the longest booked streak, omitted if there is none
+	LongestBooked *Streak `json:"longest_booked,omitempty"`
+	// Streaks contains all consecutive runs of days with the same status in date order
+	Streaks []Streak `json:"streaks"`
+}
+
+// Streak represents consecutive calendar days with the same booking status.
+type Streak struct {
+	// Status is DayStatusBooked or DayStatusAvailable
+	Status string `json:"status"`
+	// Start is the first day of the streak in YYYY-MM-DD format
+	Start string `json:"start"`
+	// End is the last day of the streak in YYYY-MM-DD format
+	End string `json:"end"`
+	// Nights is the number of days in the streak
+	Nights int `json:"nights"`
+}
+
+// RoomComparison represents analytics of several rooms over the same windows.
+type RoomComparison struct {
+	// Currency is the currency rates were converted to or, without
+	// conversion, the currency all rooms share
+	Currency string `json:"currency,omitempty"`
+	// Window describes the date ranges the analytics cover
+	Window AnalysisWindow `json:"window"`
+	// Months lists the months of the occupancy window in YYYY-MM format
+	Months []string `json:"months"`
+	// GroupAverage contains the average metrics of all compared rooms
+	GroupAverage ComparisonMetrics `json:"group_average"`
+	// Rooms contains the analytics of each room in request order
+	Rooms []ComparedRoom `json:"rooms"`
+}
+
+// ComparisonMetrics represents the headline metrics used to compare rooms.
+type ComparisonMetrics struct {
+	// OccupancyPercentage is the share of booked days in the occupancy window
+	OccupancyPercentage float64 `json:"occupancy_percentage"`
+	// AverageRate is the mean rate in the rate window
+	AverageRate float64 `json:"average_rate"`
+	// ADR is the average daily rate of booked nights in the rate window
+	ADR float64 `json:"adr"`
+	// RevPAR is the revenue per available night in the rate window
+	RevPAR float64 `json:"revpar"`
+}
+
+// ComparedRoom represents a room's analytics relative to its comparison group.
+type ComparedRoom struct {
+	// RoomID uniquely identifies the room
+	RoomID string `json:"room_id"`
+	// Metrics contains the room's headline metrics
+	Metrics ComparisonMetrics `json:"metrics"`
+	// Deltas contains the room's metrics minus the group average
+	Deltas ComparisonMetrics `json:"deltas"`
+	// MonthlyOccupancy contains one entry per month of the comparison
+	MonthlyOccupancy []ComparedMonth `json:"monthly_occupancy"`
+	// RateAnalytics contains the full rate analytics of the room
+	RateAnalytics RateAnalytics `json:"rate_analytics"`
+}
+
+// ComparedMonth represents a room's occupancy in a month relative to the group.
+type ComparedMonth struct {
+	// Month is the month in YYYY-MM format
+	Month string `json:"month"`
+	// OccupancyPercentage is the room's occupancy, null without data
+	OccupancyPercentage *float64 `json:"occupancy_percentage"`
+	// GroupAverage is the average occupancy of rooms with data, null if none has data
+	GroupAverage *float64 `json:"group_average"`
+	// Delta is the room's occupancy minus the group average, null if either is missing
+	Delta *float64 `json:"delta"`
+}
+
+// Availability represents the rooms free for a whole stay.
+type Availability struct {
+	// CheckIn is the first night of the stay in YYYY-MM-DD format
+	CheckIn string `json:"check_in"`
+	// CheckOut is the departure day in YYYY-MM-DD format
+	CheckOut string `json:"check_out"`
+	// Nights is the number of nights of the stay
+	Nights int `json:"nights"`
+	// Rooms contains the available rooms ordered by room ID
+	Rooms []AvailableRoom `json:"rooms"`
+}
+
+// AvailableRoom represents a room with every night of a stay known and unbooked.
+type AvailableRoom struct {
+	// RoomID is the unique identifier of the room
+	RoomID string `json:"room_id"`
+	// Currency is the ISO 4217 code the rates are quoted in
+	Currency string `json:"currency"`
+	// TotalRate is the sum of the rates of the stay's nights
+	TotalRate float64 `json:"total_rate"`
+	// NightlyRate is the average rate per night of the stay
+	NightlyRate float64 `json:"nightly_rate"`
 }
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repositorytest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testRepositories returns an empty memory repository and an empty migrated
+// SQLite repository.
+func testRepositories(t *testing.T) map[string]RoomRepository {
+	return map[string]RoomRepository{
+		"memory": NewMemoryRoomRepository(),
+		"sqlite": NewSQLiteRoomRepository(repositorytest.NewSQLiteDB(t)),
+	}
+}
+
+// seedAvailability stores five days from 2025-03-01 for rooms that are free,
+// have a gap, are booked on the first or last day, have an expensive day or
+// are priced in euros.
+func seedAvailability(t *testing.T, repo RoomRepository) {
+	t.Helper()
+
+	if err := repo.CreateRoom(models.Room{RoomID: "EURO", RoomAttributes: models.RoomAttributes{Currency: "EUR", TimeZone: "UTC"}}); err != nil {
+		t.Fatalf("creating room: %v", err)
+	}
+
+	var bookings []models.RoomBooking
+	for _, roomID := range []string{"BOOKED_END", "BOOKED_START", "EURO", "FREE", "GAP", "PRICEY"} {
+		for day := 1; day <= 5; day++ {
+			date := time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+			booking := models.RoomBooking{RoomID: roomID, RoomData: models.RoomData{Date: date.Format("2006-01-02"), Rate: 100}}
+			switch {
+			case roomID == "GAP" && day == 3:
+				continue
+			case roomID == "BOOKED_END" && day == 5, roomID == "BOOKED_START" && day == 1:
+				booking.IsBooked = true
+			case roomID == "PRICEY" && day == 2:
+				booking.Rate = 300
+			}
+			bookings = append(bookings, booking)
+		}
+	}
+	if err := repo.UpsertBookings(bookings, time.Now()); err != nil {
+		t.Fatalf("seeding bookings: %v", err)
+	}
+}
+
+func TestFindAvailableRooms(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	room := func(roomID, currency string, totalRate float64) models.AvailableRoom {
+		return models.AvailableRoom{RoomID: roomID, Currency: currency, TotalRate: totalRate}
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		maxRate    float64
+		want       []models.AvailableRoom
+	}{
+		{
+			name:  "a booked day after the range does not matter",
+			start: day(1),
+			end:   day(4),
+			want: []models.AvailableRoom{
+				room("BOOKED_END", "USD", 400),
+				room("EURO", "EUR", 400),
+				room("FREE", "USD", 400),
+				room("PRICEY", "USD", 600),
+			},
+		},
+		{
+			name:  "every day of the range must be known and unbooked",
+			start: day(1),
+			end:   day(5),
+			want: []models.AvailableRoom{
+				room("EURO", "EUR", 500),
+				room("FREE", "USD", 500),
+				room("PRICEY", "USD", 700),
+			},
+		},
+		{
+			name:  "a single day",
+			start: day(3),
+			end:   day(3),
+			want: []models.AvailableRoom{
+				room("BOOKED_END", "USD", 100),
+				room("BOOKED_START", "USD", 100),
+				room("EURO", "EUR", 100),
+				room("FREE", "USD", 100),
+				room("PRICEY", "USD", 100),
+			},
+		},
+		{
+			name:    "max rate excludes rooms with a more expensive day",
+			start:   day(1),
+			end:     day(4),
+			maxRate: 200,
+			want: []models.AvailableRoom{
+				room("BOOKED_END", "USD", 400),
+				room("EURO", "EUR", 400),
+				room("FREE", "USD", 400),
+			},
+		},
+		{
+			name:  "days without data make no room available",
+			start: day(4),
+			end:   day(6),
+		},
+	}
+
+	for name, repo := range testRepositories(t) {
+		seedAvailability(t, repo)
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got, err := repo.FindAvailableRooms(tt.start, tt.end, tt.maxRate)
+				if err != nil {
+					t.Fatalf("FindAvailableRooms() error = %v", err)
+				}
+				if len(got) != 0 || len(tt.want) != 0 {
+					if !reflect.DeepEqual(got, tt.want) {
+						t.Errorf("FindAvailableRooms() = %+v, want %+v", got, tt.want)
+					}
+				}
+			})
+		}
+	}
+}
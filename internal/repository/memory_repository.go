@@ -0,0 +1,791 @@
+package repository
+
+import (
+	"airbnb-analytics/internal/importer"
+	"airbnb-analytics/internal/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRoomRepository keeps room data in memory.
+// It is intended for local development and tests where no database is available.
+type MemoryRoomRepository struct {
+	mu sync.RWMutex
+	// rooms maps room IDs to their daily data keyed by "YYYY-MM-DD" date
+	rooms map[string]map[string]models.RoomData
+	// history maps room IDs to the recorded changes of their days in write order
+	history map[string][]models.BookingChange
+	// reservations maps room IDs to their reservations in insertion order
+	reservations map[string][]models.Reservation
+	// nextReservationID is the ID assigned to the next reservation
+	nextReservationID int64
+	// listings maps room IDs to their room records
+	listings map[string]models.Room
+	// exchangeRates maps currencies to their rates keyed by "YYYY-MM-DD" date
+	exchangeRates map[string]map[string]float64
+}
+
+// NewMemoryRoomRepository creates a new, empty in-memory repository.
+// Returns:
+//   - *MemoryRoomRepository: New repository instance
+func NewMemoryRoomRepository() *MemoryRoomRepository {
+	return &MemoryRoomRepository{
+		rooms:             make(map[string]map[string]models.RoomData),
+		history:           make(map[string][]models.BookingChange),
+		reservations:      make(map[string][]models.Reservation),
+		nextReservationID: 1,
+		listings:          make(map[string]models.Room),
+		exchangeRates:     make(map[string]map[string]float64),
+	}
+}
+
+// GetRoomData retrieves room booking data for a given date range.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.RoomData: Slice of room booking data ordered by date
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetRoomData(roomID string, startDate, endDate time.Time) ([]models.RoomData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from := startDate.Format("2006-01-02")
+	to := endDate.Format("2006-01-02")
+
+	var bookings []models.RoomData
+	for date, booking := range r.rooms[roomID] {
+		if date >= from && date <= to {
+			bookings = append(bookings, booking)
+		}
+	}
+
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].Date < bookings[j].Date
+	})
+
+	return bookings, nil
+}
+
+// GetBookings retrieves booking data for several rooms for a given date range.
+// Parameters:
+//   - roomIDs []string: Rooms to include, all rooms if empty
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.RoomBooking: Booking data ordered by room and date
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetBookings(roomIDs []string, startDate, endDate time.Time) ([]models.RoomBooking, error) {
+	if len(roomIDs) == 0 {
+		roomIDs, _ = r.GetAllRoomIDs()
+	}
+
+	selected := make(map[string]bool)
+	var bookings []models.RoomBooking
+	for _, id := range roomIDs {
+		if selected[id] {
+			continue
+		}
+		selected[id] = true
+
+		days, _ := r.GetRoomData(id, startDate, endDate)
+		for _, day := range days {
+			bookings = append(bookings, models.RoomBooking{RoomID: id, RoomData: day})
+		}
+	}
+
+	sort.SliceStable(bookings, func(i, j int) bool {
+		return bookings[i].RoomID < bookings[j].RoomID
+	})
+
+	return bookings, nil
+}
+
+// GetBookingHistory retrieves every recorded state of a room's days within a
+// date range. Changes are recorded by the upsert methods.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.BookingChange: Changes ordered by date and time of change
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetBookingHistory(roomID string, startDate, endDate time.Time) ([]models.BookingChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from := startDate.Format("2006-01-02")
+	to := endDate.Format("2006-01-02")
+
+	var changes []models.BookingChange
+	for _, change := range r.history[roomID] {
+		if change.Date >= from && change.Date <= to {
+			changes = append(changes, change)
+		}
+	}
+
+	// Stable sort keeps write order for changes recorded at the same time
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Date != changes[j].Date {
+			return changes[i].Date < changes[j].Date
+		}
+		return changes[i].ChangedAt.Before(changes[j].ChangedAt)
+	})
+
+	return changes, nil
+}
+
+// FindAvailableRooms retrieves the rooms free for every day of a date range.
+// Parameters:
+//   - startDate time.Time: First day of the range
+//   - endDate time.Time: Last day of the range
+//   - maxRate float64: Highest rate allowed for any day, 0 for no limit
+//
+// Returns:
+//   - []models.AvailableRoom: Available rooms with their total rate, ordered by room ID
+//   - error: Always nil
+func (r *MemoryRoomRepository) FindAvailableRooms(startDate, endDate time.Time, maxRate float64) ([]models.AvailableRoom, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rooms []models.AvailableRoom
+	for id, days := range r.rooms {
+		available := true
+		room := models.AvailableRoom{RoomID: id, Currency: models.DefaultCurrency}
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			day, ok := days[date.Format("2006-01-02")]
+			if !ok || day.IsBooked || (maxRate > 0 && day.Rate > maxRate) {
+				available = false
+				break
+			}
+			room.TotalRate += day.Rate
+		}
+		if !available {
+			continue
+		}
+
+		if listing, ok := r.listings[id]; ok {
+			room.Currency = listing.Currency
+		}
+		rooms = append(rooms, room)
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].RoomID < rooms[j].RoomID
+	})
+
+	return rooms, nil
+}
+
+// SearchRooms retrieves a page of rooms with their booking metrics.
+// Parameters:
+//   - search models.RoomSearchQuery: Filters, order and page of the search
+//
+// Returns:
+//   - []models.RoomSearchMatch: Rooms of the page in the requested order
+//   - error: Error if the sort order is unsupported
+func (r *MemoryRoomRepository) SearchRooms(search models.RoomSearchQuery) ([]models.RoomSearchMatch, error) {
+	if _, ok := searchSortColumns[search.Sort]; !ok {
+		return nil, fmt.Errorf("unsupported search order %q", search.Sort)
+	}
+
+	rooms, err := r.ListRooms(search.RoomFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := make(map[string]float64, len(search.Factors))
+	for _, factor := range search.Factors {
+		factors[factor.Currency+" "+factor.Date] = factor.Factor
+	}
+	within := func(value float64, min, max *float64) bool {
+		return (min == nil || value >= *min) && (max == nil || value <= *max)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.RoomSearchMatch
+	for _, room := range rooms {
+		if !r.availableFor(room.RoomID, search.CheckIn, search.CheckOut) {
+			continue
+		}
+
+		var rates, revenue float64
+		match := models.RoomSearchMatch{Room: room}
+		for date := search.From; !date.After(search.To); date = date.AddDate(0, 0, 1) {
+			day, ok := r.rooms[room.RoomID][date.Format("2006-01-02")]
+			if !ok {
+				continue
+			}
+			if factor, ok := factors[room.Currency+" "+day.Date]; ok {
+				day.Rate *= factor
+			}
+			match.Nights++
+			rates += day.Rate
+			if day.IsBooked {
+				match.BookedNights++
+				revenue += day.Rate
+			}
+		}
+		if match.Nights == 0 {
+			continue
+		}
+
+		match.OccupancyPercentage = float64(match.BookedNights) * 100 / float64(match.Nights)
+		match.AverageRate = rates / float64(match.Nights)
+		if match.BookedNights > 0 {
+			match.ADR = revenue / float64(match.BookedNights)
+		}
+		match.RevPAR = revenue / float64(match.Nights)
+
+		if within(match.OccupancyPercentage, search.MinOccupancy, search.MaxOccupancy) &&
+			within(match.AverageRate, search.MinRate, search.MaxRate) &&
+			(search.After == nil || searchFollows(search, match, *search.After)) {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return searchFollows(search, matches[j], searchPosition(search.Sort, matches[i]))
+	})
+	if len(matches) > search.Limit {
+		matches = matches[:search.Limit]
+	}
+
+	return matches, nil
+}
+
+// availableFor reports whether a room has an unbooked row for every night of
+// a stay. The caller must hold the read lock.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - checkIn time.Time: First night of the stay
+//   - checkOut time.Time: Day after the last night, equal to checkIn for no stay
+//
+// Returns:
+//   - bool: True if the room is free for the whole stay
+func (r *MemoryRoomRepository) availableFor(roomID string, checkIn, checkOut time.Time) bool {
+	for date := checkIn; date.Before(checkOut); date = date.AddDate(0, 0, 1) {
+		day, ok := r.rooms[roomID][date.Format("2006-01-02")]
+		if !ok || day.IsBooked {
+			return false
+		}
+	}
+	return true
+}
+
+// searchPosition returns the position of a match in the order of a room search.
+// Parameters:
+//   - sortKey string: One of the models.SearchSort constants
+//   - match models.RoomSearchMatch: Room with its metrics
+//
+// Returns:
+//   - models.RoomSearchPosition: The match's sort metric and room ID
+func searchPosition(sortKey string, match models.RoomSearchMatch) models.RoomSearchPosition {
+	position := models.RoomSearchPosition{RoomID: match.RoomID}
+	switch sortKey {
+	case models.SearchSortOccupancy:
+		position.Value = match.OccupancyPercentage
+	case models.SearchSortAverageRate:
+		position.Value = match.AverageRate
+	case models.SearchSortADR:
+		position.Value = match.ADR
+	case models.SearchSortRevPAR:
+		position.Value = match.RevPAR
+	case models.SearchSortBookedNights:
+		position.Value = float64(match.BookedNights)
+	}
+	return position
+}
+
+// searchFollows reports whether a match comes after a position in the order
+// of a room search, matching the keyset condition of the SQL repository.
+// Parameters:
+//   - search models.RoomSearchQuery: Search whose order to use
+//   - match models.RoomSearchMatch: Room with its metrics
+//   - position models.RoomSearchPosition: Position to compare with
+//
+// Returns:
+//   - bool: True if the match sorts after the position
+func searchFollows(search models.RoomSearchQuery, match models.RoomSearchMatch, position models.RoomSearchPosition) bool {
+	if search.Sort == models.SearchSortRoomID {
+		return match.RoomID != position.RoomID && (match.RoomID > position.RoomID) != search.Descending
+	}
+	value := searchPosition(search.Sort, match).Value
+	if value != position.Value {
+		return (value > position.Value) != search.Descending
+	}
+	return match.RoomID > position.RoomID
+}
+
+// GetAllRoomIDs retrieves all unique room identifiers.
+// Returns:
+//   - []string: List of room IDs in ascending order
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetAllRoomIDs() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []string
+	for id := range r.rooms {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// ListRooms retrieves the room records matching a filter.
+// Parameters:
+//   - filter models.RoomFilter: Attributes to match, zero values match any room
+//
+// Returns:
+//   - []models.Room: Matching rooms ordered by room ID
+//   - error: Always nil
+func (r *MemoryRoomRepository) ListRooms(filter models.RoomFilter) ([]models.Room, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := func(value, wanted string) bool {
+		return wanted == "" || strings.EqualFold(value, wanted)
+	}
+
+	var rooms []models.Room
+	for _, room := range r.listings {
+		if matches(room.City, filter.City) &&
+			matches(room.Neighborhood, filter.Neighborhood) &&
+			matches(room.PropertyType, filter.PropertyType) &&
+			matches(room.Currency, filter.Currency) &&
+			room.Bedrooms >= filter.MinBedrooms &&
+			room.Capacity >= filter.MinCapacity {
+			rooms = append(rooms, room)
+		}
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].RoomID < rooms[j].RoomID
+	})
+
+	return rooms, nil
+}
+
+// GetRoom retrieves the record of a room.
+// Parameters:
+//   - roomID string: Room identifier
+//
+// Returns:
+//   - *models.Room: The room, nil if it has no record
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetRoom(roomID string) (*models.Room, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	room, ok := r.listings[roomID]
+	if !ok {
+		return nil, nil
+	}
+	return &room, nil
+}
+
+// CreateRoom stores a new room record.
+// Parameters:
+//   - room models.Room: Room to store
+//
+// Returns:
+//   - error: ErrRoomExists if the room already has a record
+func (r *MemoryRoomRepository) CreateRoom(room models.Room) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.listings[room.RoomID]; ok {
+		return ErrRoomExists
+	}
+	r.listings[room.RoomID] = room
+	return nil
+}
+
+// UpdateRoom replaces the attributes of a room record.
+// Parameters:
+//   - room models.Room: Room with its new attributes
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record
+func (r *MemoryRoomRepository) UpdateRoom(room models.Room) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.listings[room.RoomID]; !ok {
+		return ErrRoomNotFound
+	}
+	r.listings[room.RoomID] = room
+	return nil
+}
+
+// DeleteRoom removes a room record together with its calendar, booking
+// history and reservations.
+// Parameters:
+//   - roomID string: Room identifier
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record
+func (r *MemoryRoomRepository) DeleteRoom(roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.listings[roomID]; !ok {
+		return ErrRoomNotFound
+	}
+	delete(r.listings, roomID)
+	delete(r.rooms, roomID)
+	delete(r.history, roomID)
+	delete(r.reservations, roomID)
+	return nil
+}
+
+// GetReservations retrieves the reservations of a room checking in within a date range.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: First check-in day to include
+//   - endDate time.Time: Last check-in day to include
+//
+// Returns:
+//   - []models.Reservation: Reservations ordered by check-in
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetReservations(roomID string, startDate, endDate time.Time) ([]models.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from := startDate.Format("2006-01-02")
+	to := endDate.Format("2006-01-02")
+
+	var reservations []models.Reservation
+	for _, reservation := range r.reservations[roomID] {
+		if reservation.CheckIn >= from && reservation.CheckIn <= to {
+			reservations = append(reservations, reservation)
+		}
+	}
+
+	sort.SliceStable(reservations, func(i, j int) bool {
+		return reservations[i].CheckIn < reservations[j].CheckIn
+	})
+
+	return reservations, nil
+}
+
+// CreateReservation stores a reservation and marks its nights booked.
+// Either both are stored or neither.
+// Parameters:
+//   - reservation models.Reservation: Reservation to store, its ID is ignored
+//   - nights []models.RoomBooking: Calendar days of the stay
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - models.Reservation: The stored reservation with its assigned ID
+//   - error: ErrReservationOverlap if the stay overlaps another reservation,
+//     or an error describing the first invalid night
+func (r *MemoryRoomRepository) CreateReservation(reservation models.Reservation, nights []models.RoomBooking, changedAt time.Time) (models.Reservation, error) {
+	if err := validateBookings(nights); err != nil {
+		return models.Reservation{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.reservations[reservation.RoomID] {
+		if existing.CheckIn < reservation.CheckOut && existing.CheckOut > reservation.CheckIn {
+			return models.Reservation{}, ErrReservationOverlap
+		}
+	}
+
+	reservation.ID = r.nextReservationID
+	r.nextReservationID++
+	r.reservations[reservation.RoomID] = append(r.reservations[reservation.RoomID], reservation)
+
+	r.store(nights, changedAt, func(_, booking models.RoomData) models.RoomData {
+		return booking
+	})
+
+	return reservation, nil
+}
+
+// GetExchangeRates retrieves the exchange rates of several currencies for a given date range.
+// Parameters:
+//   - currencies []string: ISO 4217 codes of the currencies to include
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.ExchangeRate: Exchange rates ordered by currency and date
+//   - error: Always nil
+func (r *MemoryRoomRepository) GetExchangeRates(currencies []string, startDate, endDate time.Time) ([]models.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from := startDate.Format("2006-01-02")
+	to := endDate.Format("2006-01-02")
+
+	var rates []models.ExchangeRate
+	for _, currency := range currencies {
+		for date, rate := range r.exchangeRates[currency] {
+			if date >= from && date <= to {
+				rates = append(rates, models.ExchangeRate{Currency: currency, Date: date, Rate: rate})
+			}
+		}
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return rates[i].Date < rates[j].Date
+	})
+
+	return rates, nil
+}
+
+// UpsertExchangeRates inserts the given exchange rates, replacing the rates of
+// currencies and days that already exist. Either all rates are stored or none.
+// Parameters:
+//   - rates []models.ExchangeRate: Daily exchange rates to write
+//
+// Returns:
+//   - error: Error describing the first invalid rate
+func (r *MemoryRoomRepository) UpsertExchangeRates(rates []models.ExchangeRate) error {
+	for _, rate := range rates {
+		if rate.Currency == "" {
+			return fmt.Errorf("currency is required")
+		}
+		if _, err := time.Parse("2006-01-02", rate.Date); err != nil {
+			return fmt.Errorf("invalid date %q for currency %s", rate.Date, rate.Currency)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rate := range rates {
+		days, ok := r.exchangeRates[rate.Currency]
+		if !ok {
+			days = make(map[string]float64)
+			r.exchangeRates[rate.Currency] = days
+		}
+		days[rate.Date] = rate.Rate
+	}
+	return nil
+}
+
+// UpsertBookings inserts the given bookings, replacing the booking status and
+// rate of days that already exist. Either all bookings are stored or none.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily bookings to write
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - error: Error describing the first invalid booking
+func (r *MemoryRoomRepository) UpsertBookings(bookings []models.RoomBooking, changedAt time.Time) error {
+	return r.upsert(bookings, changedAt, func(_, booking models.RoomData) models.RoomData {
+		return booking
+	})
+}
+
+// UpsertBookingStatus updates the booking status of the given days, keeping
+// the rates of days that already exist. Days that don't exist yet are inserted
+// with the booking's rate. Either all bookings are stored or none.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily booking statuses to write
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - error: Error describing the first invalid booking
+func (r *MemoryRoomRepository) UpsertBookingStatus(bookings []models.RoomBooking, changedAt time.Time) error {
+	return r.upsert(bookings, changedAt, func(existing, booking models.RoomData) models.RoomData {
+		existing.IsBooked = booking.IsBooked
+		return existing
+	})
+}
+
+// upsert validates and stores bookings, using merge to combine a new booking
+// with the day it replaces. Every new day and every change in booking status
+// or rate is recorded in the history.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily bookings to write
+//   - changedAt time.Time: Time recorded in the booking history
+//   - merge func(existing, booking models.RoomData) models.RoomData: Conflict resolution
+//
+// Returns:
+//   - error: Error describing the first invalid booking
+func (r *MemoryRoomRepository) upsert(bookings []models.RoomBooking, changedAt time.Time, merge func(existing, booking models.RoomData) models.RoomData) error {
+	// Validate everything up front so a failure leaves the data untouched
+	if err := validateBookings(bookings); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store(bookings, changedAt, merge)
+	return nil
+}
+
+// validateBookings checks that bookings can be stored.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily bookings to check
+//
+// Returns:
+//   - error: Error describing the first invalid booking
+func validateBookings(bookings []models.RoomBooking) error {
+	for _, booking := range bookings {
+		if booking.RoomID == "" {
+			return fmt.Errorf("room ID is required")
+		}
+		if _, err := time.Parse("2006-01-02", booking.Date); err != nil {
+			return fmt.Errorf("invalid date %q for room %s", booking.Date, booking.RoomID)
+		}
+	}
+	return nil
+}
+
+// store writes validated bookings and records their changes in the history.
+// Rooms without a record are registered with default attributes.
+// The caller must hold the write lock.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily bookings to write
+//   - changedAt time.Time: Time recorded in the booking history
+//   - merge func(existing, booking models.RoomData) models.RoomData: Conflict resolution
+func (r *MemoryRoomRepository) store(bookings []models.RoomBooking, changedAt time.Time, merge func(existing, booking models.RoomData) models.RoomData) {
+	changedAt = changedAt.UTC()
+	for _, booking := range bookings {
+		if _, ok := r.listings[booking.RoomID]; !ok {
+			r.listings[booking.RoomID] = models.Room{
+				RoomID:         booking.RoomID,
+				RoomAttributes: models.RoomAttributes{Currency: models.DefaultCurrency, TimeZone: models.DefaultTimeZone},
+			}
+		}
+
+		days, ok := r.rooms[booking.RoomID]
+		if !ok {
+			days = make(map[string]models.RoomData)
+			r.rooms[booking.RoomID] = days
+		}
+
+		day := booking.RoomData
+		existing, ok := days[booking.Date]
+		if ok {
+			day = merge(existing, booking.RoomData)
+		}
+		days[booking.Date] = day
+
+		if !ok || day.IsBooked != existing.IsBooked || day.Rate != existing.Rate {
+			r.history[booking.RoomID] = append(r.history[booking.RoomID], models.BookingChange{
+				RoomBooking: models.RoomBooking{RoomID: booking.RoomID, RoomData: day},
+				ChangedAt:   changedAt,
+			})
+		}
+	}
+}
+
+// LoadFile seeds the repository from a JSON or CSV file.
+// The format is selected by the file extension (".json" or ".csv").
+// Parameters:
+//   - path string: Path of the seed file
+//
+// Returns:
+//   - error: Any error encountered while reading or parsing the file
+func (r *MemoryRoomRepository) LoadFile(path string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening seed file: %v", err)
+	}
+
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing seed file: %v", closeErr)
+		}
+	}()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return r.LoadJSON(file)
+	case ".csv":
+		return r.LoadCSV(file)
+	default:
+		return fmt.Errorf("unsupported seed file format: %s", path)
+	}
+}
+
+// LoadExchangeRatesFile seeds the repository's exchange rates from CSV data
+// with the header "currency,date,rate".
+// Parameters:
+//   - path string: Path of the CSV file
+//
+// Returns:
+//   - error: Any error encountered while reading, parsing or storing the rates
+func (r *MemoryRoomRepository) LoadExchangeRatesFile(path string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening exchange rate file: %v", err)
+	}
+
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing exchange rate file: %v", closeErr)
+		}
+	}()
+
+	rates, rowErrors, err := importer.ParseExchangeRatesCSV(file)
+	if err != nil {
+		return err
+	}
+	if len(rowErrors) > 0 {
+		return fmt.Errorf("invalid exchange rate data on line %d: %s", rowErrors[0].Line, rowErrors[0].Error)
+	}
+
+	return r.UpsertExchangeRates(rates)
+}
+
+// LoadJSON seeds the repository from a JSON array of bookings, e.g.
+// [{"room_id": "A123", "date": "2025-01-01", "is_booked": true, "rate": 120.5}].
+// Parameters:
+//   - reader io.Reader: Source of the JSON document
+//
+// Returns:
+//   - error: Any error encountered while parsing or storing the bookings
+func (r *MemoryRoomRepository) LoadJSON(reader io.Reader) error {
+	var bookings []models.RoomBooking
+	if err := json.NewDecoder(reader).Decode(&bookings); err != nil {
+		return fmt.Errorf("error decoding JSON seed data: %v", err)
+	}
+
+	return r.UpsertBookings(bookings, time.Now())
+}
+
+// LoadCSV seeds the repository from CSV data with the header
+// "room_id,date,is_booked,rate".
+// Parameters:
+//   - reader io.Reader: Source of the CSV data
+//
+// Returns:
+//   - error: Any error encountered while parsing or storing the bookings
+func (r *MemoryRoomRepository) LoadCSV(reader io.Reader) error {
+	bookings, rowErrors, err := importer.ParseCSV(reader)
+	if err != nil {
+		return err
+	}
+	if len(rowErrors) > 0 {
+		return fmt.Errorf("invalid CSV seed data on line %d: %s", rowErrors[0].Line, rowErrors[0].Error)
+	}
+
+	return r.UpsertBookings(bookings, time.Now())
+}
@@ -1,107 +1,76 @@
 package repository
 
 import (
-	"airbnb-analytics/internal/database"
 	"airbnb-analytics/internal/models"
-	"database/sql"
-	"fmt"
+	"errors"
 	"time"
 )
 
-// RoomRepository handles database operations for room data
-type RoomRepository struct {
-	db *sql.DB
-}
-
-// NewRoomRepository creates a new repository instance with database connection.
-// Returns:
-//   - *RoomRepository: New repository instance
-func NewRoomRepository() *RoomRepository {
-	return &RoomRepository{
-		db: database.DB,
-	}
-}
-
-// GetRoomData retrieves room booking data for a given date range.
-// Parameters:
-//   - roomID string: Room identifier
-//   - startDate time.Time: Start of date range
-//   - endDate time.Time: End of date range
-//
-// Returns:
-//   - []models.RoomData: Slice of room booking data
-//   - error: Any error encountered
-func (r *RoomRepository) GetRoomData(roomID string, startDate, endDate time.Time) (roomData []models.RoomData, err error) {
-	query := `
-        SELECT date::date, is_booked, rate 
-        FROM room_bookings 
-        WHERE room_id = $1 
-        AND date::date >= $2::date 
-        AND date::date <= $3::date
-        ORDER BY date
-    `
-
-	rows, err := r.db.Query(query, roomID, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("error querying room data: %v", err)
-	}
-
-	// Using named return to handle close error
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("error closing rows: %v", closeErr)
-		}
-	}()
-
-	var bookings []models.RoomData
-	for rows.Next() {
-		var booking models.RoomData
-		var date time.Time
-		if err := rows.Scan(&date, &booking.IsBooked, &booking.Rate); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
-		}
-		booking.Date = date.Format("2006-01-02")
-		bookings = append(bookings, booking)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %v", err)
-	}
-
-	return bookings, nil
-}
-
-// GetAllRoomIDs retrieves all unique room identifiers.
-// Returns:
-//   - []string: List of room IDs
-//   - error: Any error encountered
-func (r *RoomRepository) GetAllRoomIDs() (roomIDs []string, err error) {
-	query := `SELECT DISTINCT room_id FROM room_bookings ORDER BY room_id`
-
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("error querying room IDs: %v", err)
-	}
-
-	// Using named return to handle close error
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("error closing rows: %v", closeErr)
-		}
-	}()
+// ErrReservationOverlap is returned when a reservation overlaps an existing one
+var ErrReservationOverlap = errors.New("reservation overlaps an existing reservation")
 
-	var ids []string
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, fmt.Errorf("error scanning room ID: %v", err)
-		}
-		ids = append(ids, id)
-	}
+// ErrRoomExists is returned when creating a room whose ID is already taken
+var ErrRoomExists = errors.New("room already exists")
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating room IDs: %v", err)
-	}
+// ErrRoomNotFound is returned when updating or deleting a room without a record
+var ErrRoomNotFound = errors.New("room not found")
 
-	return ids, nil
+// RoomRepository defines the storage operations required by the service layer.
+// Implementations must be safe for concurrent use.
+type RoomRepository interface {
+	// GetRoomData retrieves booking data for a room within an inclusive date range,
+	// ordered by date.
+	GetRoomData(roomID string, startDate, endDate time.Time) ([]models.RoomData, error)
+	// GetBookings retrieves booking data for several rooms within an inclusive
+	// date range in a single pass, ordered by room and date. An empty roomIDs
+	// slice selects all rooms.
+	GetBookings(roomIDs []string, startDate, endDate time.Time) ([]models.RoomBooking, error)
+	// SearchRooms retrieves a page of rooms with their booking metrics,
+	// filtered, ordered and paged as described by the query.
+	SearchRooms(query models.RoomSearchQuery) ([]models.RoomSearchMatch, error)
+	// FindAvailableRooms retrieves the rooms whose every day within an
+	// inclusive date range is known and unbooked, with the total rate of those
+	// days, ordered by room ID. A positive maxRate also excludes rooms with a
+	// day priced above it.
+	FindAvailableRooms(startDate, endDate time.Time, maxRate float64) ([]models.AvailableRoom, error)
+	// GetAllRoomIDs retrieves all unique room identifiers in ascending order.
+	GetAllRoomIDs() ([]string, error)
+	// ListRooms retrieves the room records matching a filter, ordered by room ID.
+	ListRooms(filter models.RoomFilter) ([]models.Room, error)
+	// GetRoom retrieves a room record, returning nil if the room has none.
+	GetRoom(roomID string) (*models.Room, error)
+	// CreateRoom stores a new room record. It fails with ErrRoomExists if the
+	// room already has one.
+	CreateRoom(room models.Room) error
+	// UpdateRoom replaces the attributes of a room record. It fails with
+	// ErrRoomNotFound if the room has none.
+	UpdateRoom(room models.Room) error
+	// DeleteRoom removes a room record together with its calendar, booking
+	// history and reservations. It fails with ErrRoomNotFound if the room has
+	// no record.
+	DeleteRoom(roomID string) error
+	// GetBookingHistory retrieves every recorded state of a room's days within an
+	// inclusive date range, ordered by date and time of change.
+	GetBookingHistory(roomID string, startDate, endDate time.Time) ([]models.BookingChange, error)
+	// GetReservations retrieves the reservations of a room checking in within an
+	// inclusive date range, ordered by check-in.
+	GetReservations(roomID string, startDate, endDate time.Time) ([]models.Reservation, error)
+	// CreateReservation stores a reservation and upserts the calendar days of
+	// its nights atomically, returning the reservation with its assigned ID.
+	// It fails with ErrReservationOverlap if the stay overlaps another
+	// reservation of the room. The nights' history is stamped with changedAt.
+	CreateReservation(reservation models.Reservation, nights []models.RoomBooking, changedAt time.Time) (models.Reservation, error)
+	// GetExchangeRates retrieves the exchange rates of the given currencies
+	// within an inclusive date range, ordered by currency and date.
+	GetExchangeRates(currencies []string, startDate, endDate time.Time) ([]models.ExchangeRate, error)
+	// UpsertExchangeRates inserts or replaces the given daily exchange rates atomically.
+	UpsertExchangeRates(rates []models.ExchangeRate) error
+	// UpsertBookings inserts or replaces the given daily bookings atomically.
+	// Rooms without a record get one with default attributes, as do rooms
+	// written by the other calendar methods. Changes are recorded in the
+	// booking history at changedAt, which callers take from their clock.
+	UpsertBookings(bookings []models.RoomBooking, changedAt time.Time) error
+	// UpsertBookingStatus updates only the booking status of existing days,
+	// leaving their rates intact. Missing days are inserted with the given rate.
+	UpsertBookingStatus(bookings []models.RoomBooking, changedAt time.Time) error
 }
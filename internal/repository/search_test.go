@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"airbnb-analytics/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearchRoomsAvailability(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name              string
+		checkIn, checkOut time.Time
+		want              []string
+	}{
+		{
+			name: "without a stay every room with data matches",
+			want: []string{"BOOKED_END", "BOOKED_START", "EURO", "FREE", "GAP", "PRICEY"},
+		},
+		{
+			name:     "the night of check out does not matter",
+			checkIn:  day(1),
+			checkOut: day(5),
+			want:     []string{"BOOKED_END", "EURO", "FREE", "PRICEY"},
+		},
+		{
+			name:     "every night must be known and unbooked",
+			checkIn:  day(1),
+			checkOut: day(6),
+			want:     []string{"EURO", "FREE", "PRICEY"},
+		},
+		{
+			name:     "nights without data make no room available",
+			checkIn:  day(4),
+			checkOut: day(7),
+		},
+	}
+
+	for name, repo := range testRepositories(t) {
+		seedAvailability(t, repo)
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				matches, err := repo.SearchRooms(models.RoomSearchQuery{
+					From:     day(1),
+					To:       day(5),
+					CheckIn:  tt.checkIn,
+					CheckOut: tt.checkOut,
+					Sort:     models.SearchSortRoomID,
+					Limit:    10,
+				})
+				if err != nil {
+					t.Fatalf("SearchRooms() error = %v", err)
+				}
+
+				var got []string
+				for _, match := range matches {
+					got = append(got, match.RoomID)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("SearchRooms() rooms = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,1090 @@
+package repository
+
+import (
+	"airbnb-analytics/internal/models"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Dialect identifies the SQL database flavour a SQLRoomRepository talks to.
+type Dialect string
+
+const (
+	// DialectPostgres targets PostgreSQL via the lib/pq driver
+	DialectPostgres Dialect = "postgres"
+	// DialectSQLite targets SQLite via the go-sqlite3 driver
+	DialectSQLite Dialect = "sqlite"
+)
+
+// upsertBookingsQuery inserts a day or replaces its booking status and rate
+const upsertBookingsQuery = `
+        INSERT INTO room_bookings (room_id, date, is_booked, rate, changed_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (room_id, date)
+        DO UPDATE SET is_booked = excluded.is_booked, rate = excluded.rate, changed_at = excluded.changed_at
+    `
+
+// registerRoomQuery creates a record with default attributes for a room without one
+const registerRoomQuery = `INSERT INTO rooms (room_id) VALUES ($1) ON CONFLICT (room_id) DO NOTHING`
+
+// placeholderPattern matches PostgreSQL style positional parameters ($1, $2, ...)
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// SQLRoomRepository handles database operations for room data.
+// Queries are written in portable SQL with PostgreSQL style placeholders
+// and rewritten for the configured dialect.
+type SQLRoomRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewPostgresRoomRepository creates a new repository instance backed by PostgreSQL.
+// Parameters:
+//   - db *sql.DB: Open PostgreSQL connection
+//
+// Returns:
+//   - *SQLRoomRepository: New repository instance
+func NewPostgresRoomRepository(db *sql.DB) *SQLRoomRepository {
+	return &SQLRoomRepository{
+		db:      db,
+		dialect: DialectPostgres,
+	}
+}
+
+// NewSQLiteRoomRepository creates a new repository instance backed by SQLite.
+// Parameters:
+//   - db *sql.DB: Open SQLite connection
+//
+// Returns:
+//   - *SQLRoomRepository: New repository instance
+func NewSQLiteRoomRepository(db *sql.DB) *SQLRoomRepository {
+	return &SQLRoomRepository{
+		db:      db,
+		dialect: DialectSQLite,
+	}
+}
+
+// rebind rewrites a query's placeholders for the repository's dialect.
+// SQLite uses numbered "?NNN" parameters instead of PostgreSQL's "$N".
+// Parameters:
+//   - query string: Query using $N placeholders
+//
+// Returns:
+//   - string: Query suitable for the configured dialect
+func (r *SQLRoomRepository) rebind(query string) string {
+	if r.dialect == DialectSQLite {
+		return placeholderPattern.ReplaceAllString(query, "?$1")
+	}
+	return query
+}
+
+// GetRoomData retrieves room booking data for a given date range.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.RoomData: Slice of room booking data
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetRoomData(roomID string, startDate, endDate time.Time) (roomData []models.RoomData, err error) {
+	// Dates are compared as ISO strings so the query works on both
+	// PostgreSQL DATE columns and SQLite TEXT dates
+	query := `
+        SELECT date, is_booked, rate
+        FROM room_bookings
+        WHERE room_id = $1
+        AND date >= $2
+        AND date <= $3
+        ORDER BY date
+    `
+
+	rows, err := r.db.Query(r.rebind(query), roomID, formatDate(startDate), formatDate(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("error querying room data: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	var bookings []models.RoomData
+	for rows.Next() {
+		var booking models.RoomData
+		var date dateValue
+		if err := rows.Scan(&date, &booking.IsBooked, &booking.Rate); err != nil {
+			return nil, fmt.Errorf("error scanning row: %v", err)
+		}
+		booking.Date = string(date)
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %v", err)
+	}
+
+	return bookings, nil
+}
+
+// GetBookings retrieves booking data for several rooms for a given date range
+// with a single query.
+// Parameters:
+//   - roomIDs []string: Rooms to include, all rooms if empty
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.RoomBooking: Booking data ordered by room and date
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetBookings(roomIDs []string, startDate, endDate time.Time) (bookings []models.RoomBooking, err error) {
+	query := `
+        SELECT room_id, date, is_booked, rate
+        FROM room_bookings
+        WHERE date >= $1
+        AND date <= $2
+    `
+	args := []interface{}{formatDate(startDate), formatDate(endDate)}
+
+	if len(roomIDs) > 0 {
+		placeholders := make([]string, len(roomIDs))
+		for i, id := range roomIDs {
+			args = append(args, id)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		query += ` AND room_id IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	query += ` ORDER BY room_id, date`
+
+	rows, err := r.db.Query(r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bookings: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var booking models.RoomBooking
+		var date dateValue
+		if err := rows.Scan(&booking.RoomID, &date, &booking.IsBooked, &booking.Rate); err != nil {
+			return nil, fmt.Errorf("error scanning row: %v", err)
+		}
+		booking.Date = string(date)
+		bookings = append(bookings, booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %v", err)
+	}
+
+	return bookings, nil
+}
+
+// GetBookingHistory retrieves every recorded state of a room's days within a
+// date range. Changes are recorded by database triggers on room_bookings,
+// stamped with the change time the writer passed in.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.BookingChange: Changes ordered by date and time of change
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetBookingHistory(roomID string, startDate, endDate time.Time) (changes []models.BookingChange, err error) {
+	query := `
+        SELECT date, is_booked, rate, changed_at
+        FROM room_booking_history
+        WHERE room_id = $1
+        AND date >= $2
+        AND date <= $3
+        ORDER BY date, changed_at, id
+    `
+
+	rows, err := r.db.Query(r.rebind(query), roomID, formatDate(startDate), formatDate(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("error querying booking history: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		change := models.BookingChange{RoomBooking: models.RoomBooking{RoomID: roomID}}
+		var date dateValue
+		if err := rows.Scan(&date, &change.IsBooked, &change.Rate, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("error scanning booking change: %v", err)
+		}
+		change.Date = string(date)
+		change.ChangedAt = change.ChangedAt.UTC()
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating booking history: %v", err)
+	}
+
+	return changes, nil
+}
+
+// searchSortColumns maps room search sort orders to the metrics columns they sort by
+var searchSortColumns = map[string]string{
+	models.SearchSortRoomID:       "room_id",
+	models.SearchSortOccupancy:    "occupancy",
+	models.SearchSortAverageRate:  "average_rate",
+	models.SearchSortADR:          "adr",
+	models.SearchSortRevPAR:       "revpar",
+	models.SearchSortBookedNights: "booked_nights",
+}
+
+// SearchRooms retrieves a page of rooms with their booking metrics in a single
+// query. Metrics are aggregated per room in the database, and the filters,
+// order and keyset pagination are applied to the aggregates, so only the rows
+// of the requested page are returned.
+// Parameters:
+//   - search models.RoomSearchQuery: Filters, order and page of the search
+//
+// Returns:
+//   - []models.RoomSearchMatch: Rooms of the page in the requested order
+//   - error: Any error encountered
+func (r *SQLRoomRepository) SearchRooms(search models.RoomSearchQuery) (matches []models.RoomSearchMatch, err error) {
+	column, ok := searchSortColumns[search.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unsupported search order %q", search.Sort)
+	}
+
+	args := []interface{}{formatDate(search.From), formatDate(search.To)}
+	query := `WITH `
+	rate := `b.rate`
+	join := ``
+	if len(search.Factors) > 0 {
+		factors, err := json.Marshal(search.Factors)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding conversion factors: %v", err)
+		}
+		args = append(args, string(factors))
+		query += fmt.Sprintf(r.factorsTable(), len(args)) + `, `
+		rate = `b.rate * COALESCE(fx.factor, 1)`
+		join = `LEFT JOIN fx ON fx.currency = c.currency AND fx.date = b.date`
+	}
+
+	query += fmt.Sprintf(`metrics AS (
+            SELECT b.room_id,
+                COUNT(*) AS nights,
+                SUM(CASE WHEN b.is_booked THEN 1 ELSE 0 END) AS booked_nights,
+                CAST(SUM(CASE WHEN b.is_booked THEN 1 ELSE 0 END) * 100.0 / COUNT(*) AS DOUBLE PRECISION) AS occupancy,
+                CAST(AVG(%[1]s) AS DOUBLE PRECISION) AS average_rate,
+                CAST(COALESCE(SUM(CASE WHEN b.is_booked THEN %[1]s END) * 1.0
+                    / NULLIF(SUM(CASE WHEN b.is_booked THEN 1 ELSE 0 END), 0), 0) AS DOUBLE PRECISION) AS adr,
+                CAST(COALESCE(SUM(CASE WHEN b.is_booked THEN %[1]s END), 0) * 1.0 / COUNT(*) AS DOUBLE PRECISION) AS revpar
+            FROM room_bookings b
+            JOIN rooms c ON c.room_id = b.room_id
+            %[2]s
+            WHERE b.date >= $1
+            AND b.date <= $2`, rate, join)
+
+	if !search.CheckIn.IsZero() {
+		// A room is available if every night of the stay has a row and none is booked
+		nights := int(search.CheckOut.Sub(search.CheckIn).Hours() / 24)
+		args = append(args, formatDate(search.CheckIn), formatDate(search.CheckOut.AddDate(0, 0, -1)), nights)
+		query += fmt.Sprintf(`
+            AND b.room_id IN (
+                SELECT room_id
+                FROM room_bookings
+                WHERE date >= $%d
+                AND date <= $%d
+                GROUP BY room_id
+                HAVING COUNT(*) = $%d
+                AND SUM(CASE WHEN is_booked THEN 1 ELSE 0 END) = 0
+            )`, len(args)-2, len(args)-1, len(args))
+	}
+	query += `
+            GROUP BY b.room_id
+        )
+        SELECT ` + roomColumns + `, occupancy, average_rate, adr, revpar, booked_nights, nights
+        FROM rooms
+        JOIN metrics USING (room_id)
+        WHERE 1 = 1`
+
+	query, args = appendRoomFilter(query, args, search.RoomFilter)
+	for _, bound := range []struct {
+		condition string
+		value     *float64
+	}{
+		{"occupancy >= $%d", search.MinOccupancy},
+		{"occupancy <= $%d", search.MaxOccupancy},
+		{"average_rate >= $%d", search.MinRate},
+		{"average_rate <= $%d", search.MaxRate},
+	} {
+		if bound.value != nil {
+			args = append(args, *bound.value)
+			query += ` AND ` + fmt.Sprintf(bound.condition, len(args))
+		}
+	}
+
+	direction := `ASC`
+	comparison := `>`
+	if search.Descending {
+		direction = `DESC`
+		comparison = `<`
+	}
+	if search.Sort == models.SearchSortRoomID {
+		if search.After != nil {
+			args = append(args, search.After.RoomID)
+			query += fmt.Sprintf(` AND room_id %s $%d`, comparison, len(args))
+		}
+		query += ` ORDER BY room_id ` + direction
+	} else {
+		// Ties on the metric are ordered by room ID in either direction
+		if search.After != nil {
+			args = append(args, search.After.Value, search.After.RoomID)
+			query += fmt.Sprintf(` AND (%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND room_id > $%[4]d))`,
+				column, comparison, len(args)-1, len(args))
+		}
+		query += ` ORDER BY ` + column + ` ` + direction + `, room_id`
+	}
+	args = append(args, search.Limit)
+	query += fmt.Sprintf(` LIMIT $%d`, len(args))
+
+	rows, err := r.db.Query(r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error searching rooms: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var match models.RoomSearchMatch
+		match.Room, err = scanRoom(rows, &match.OccupancyPercentage, &match.AverageRate, &match.ADR,
+			&match.RevPAR, &match.BookedNights, &match.Nights)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results: %v", err)
+	}
+
+	return matches, nil
+}
+
+// factorsTable returns a common table expression named fx that expands a
+// JSON array of models.ConversionFactor into rows of currency, date and factor.
+// Returns:
+//   - string: Table expression with a %d verb for the placeholder number of the JSON argument
+func (r *SQLRoomRepository) factorsTable() string {
+	if r.dialect == DialectSQLite {
+		return `fx AS (
+            SELECT json_extract(value, '$.currency') AS currency,
+                json_extract(value, '$.date') AS date,
+                json_extract(value, '$.factor') AS factor
+            FROM json_each($%d)
+        )`
+	}
+	return `fx AS (
+            SELECT currency, date, factor
+            FROM json_to_recordset($%d::json) AS f(currency TEXT, date DATE, factor DOUBLE PRECISION)
+        )`
+}
+
+// FindAvailableRooms retrieves the rooms free for every day of a date range
+// with a single grouped query. A room qualifies if it has a row for each day,
+// none of them booked and, with a positive maxRate, none priced above it.
+// Parameters:
+//   - startDate time.Time: First day of the range
+//   - endDate time.Time: Last day of the range
+//   - maxRate float64: Highest rate allowed for any day, 0 for no limit
+//
+// Returns:
+//   - []models.AvailableRoom: Available rooms with their total rate, ordered by room ID
+//   - error: Any error encountered
+func (r *SQLRoomRepository) FindAvailableRooms(startDate, endDate time.Time, maxRate float64) (rooms []models.AvailableRoom, err error) {
+	days := int(endDate.Sub(startDate).Hours()/24) + 1
+
+	query := `
+        SELECT b.room_id, COALESCE(r.currency, $4), SUM(b.rate)
+        FROM room_bookings b
+        LEFT JOIN rooms r ON r.room_id = b.room_id
+        WHERE b.date >= $1
+        AND b.date <= $2
+        GROUP BY b.room_id, r.currency
+        HAVING COUNT(*) = $3
+        AND SUM(CASE WHEN b.is_booked THEN 1 ELSE 0 END) = 0
+    `
+	args := []interface{}{formatDate(startDate), formatDate(endDate), days, models.DefaultCurrency}
+
+	if maxRate > 0 {
+		args = append(args, maxRate)
+		query += ` AND MAX(b.rate) <= $5`
+	}
+	query += ` ORDER BY b.room_id`
+
+	rows, err := r.db.Query(r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying available rooms: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var room models.AvailableRoom
+		if err := rows.Scan(&room.RoomID, &room.Currency, &room.TotalRate); err != nil {
+			return nil, fmt.Errorf("error scanning row: %v", err)
+		}
+		room.Currency = strings.TrimSpace(room.Currency)
+		rooms = append(rooms, room)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %v", err)
+	}
+
+	return rooms, nil
+}
+
+// GetAllRoomIDs retrieves all unique room identifiers.
+// Returns:
+//   - []string: List of room IDs
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetAllRoomIDs() (roomIDs []string, err error) {
+	query := `SELECT DISTINCT room_id FROM room_bookings ORDER BY room_id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying room IDs: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning room ID: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating room IDs: %v", err)
+	}
+
+	return ids, nil
+}
+
+// roomColumns lists the rooms columns read into a models.Room, in scan order
+const roomColumns = `room_id, name, city, neighborhood, property_type, bedrooms, capacity, latitude, longitude, currency, timezone`
+
+// ListRooms retrieves the room records matching a filter.
+// Parameters:
+//   - filter models.RoomFilter: Attributes to match, zero values match any room
+//
+// Returns:
+//   - []models.Room: Matching rooms ordered by room ID
+//   - error: Any error encountered
+func (r *SQLRoomRepository) ListRooms(filter models.RoomFilter) (rooms []models.Room, err error) {
+	query, args := appendRoomFilter(`SELECT `+roomColumns+` FROM rooms WHERE 1 = 1`, nil, filter)
+	query += ` ORDER BY room_id`
+
+	rows, err := r.db.Query(r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying rooms: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rooms: %v", err)
+	}
+
+	return rooms, nil
+}
+
+// appendRoomFilter adds the conditions of a room filter on the rooms columns to a query.
+// Parameters:
+//   - query string: Query ending in a WHERE clause the conditions are ANDed to
+//   - args []interface{}: Arguments already bound to the query's placeholders
+//   - filter models.RoomFilter: Attributes to match, zero values match any room
+//
+// Returns:
+//   - string: The query with the filter's conditions
+//   - []interface{}: The arguments including the filter's values
+func appendRoomFilter(query string, args []interface{}, filter models.RoomFilter) (string, []interface{}) {
+	for _, condition := range []struct {
+		column string
+		value  string
+	}{
+		{"city", filter.City},
+		{"neighborhood", filter.Neighborhood},
+		{"property_type", filter.PropertyType},
+		{"currency", filter.Currency},
+	} {
+		if condition.value != "" {
+			args = append(args, condition.value)
+			query += fmt.Sprintf(` AND LOWER(%s) = LOWER($%d)`, condition.column, len(args))
+		}
+	}
+	if filter.MinBedrooms > 0 {
+		args = append(args, filter.MinBedrooms)
+		query += fmt.Sprintf(` AND bedrooms >= $%d`, len(args))
+	}
+	if filter.MinCapacity > 0 {
+		args = append(args, filter.MinCapacity)
+		query += fmt.Sprintf(` AND capacity >= $%d`, len(args))
+	}
+	return query, args
+}
+
+// GetRoom retrieves the record of a room.
+// Parameters:
+//   - roomID string: Room identifier
+//
+// Returns:
+//   - *models.Room: The room, nil if it has no record
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetRoom(roomID string) (*models.Room, error) {
+	query := `SELECT ` + roomColumns + ` FROM rooms WHERE room_id = $1`
+
+	room, err := scanRoom(r.db.QueryRow(r.rebind(query), roomID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &room, nil
+}
+
+// CreateRoom stores a new room record.
+// Parameters:
+//   - room models.Room: Room to store
+//
+// Returns:
+//   - error: ErrRoomExists if the room already has a record, or any error encountered
+func (r *SQLRoomRepository) CreateRoom(room models.Room) error {
+	query := `
+        INSERT INTO rooms (` + roomColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (room_id) DO NOTHING
+    `
+
+	result, err := r.db.Exec(r.rebind(query), room.RoomID, room.Name, room.City, room.Neighborhood,
+		room.PropertyType, room.Bedrooms, room.Capacity, room.Latitude, room.Longitude, room.Currency, room.TimeZone)
+	if err != nil {
+		return fmt.Errorf("error inserting room: %v", err)
+	}
+
+	return requireRowAffected(result, ErrRoomExists)
+}
+
+// UpdateRoom replaces the attributes of a room record.
+// Parameters:
+//   - room models.Room: Room with its new attributes
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record, or any error encountered
+func (r *SQLRoomRepository) UpdateRoom(room models.Room) error {
+	query := `
+        UPDATE rooms
+        SET name = $2, city = $3, neighborhood = $4, property_type = $5, bedrooms = $6,
+            capacity = $7, latitude = $8, longitude = $9, currency = $10, timezone = $11,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE room_id = $1
+    `
+
+	result, err := r.db.Exec(r.rebind(query), room.RoomID, room.Name, room.City, room.Neighborhood,
+		room.PropertyType, room.Bedrooms, room.Capacity, room.Latitude, room.Longitude, room.Currency, room.TimeZone)
+	if err != nil {
+		return fmt.Errorf("error updating room: %v", err)
+	}
+
+	return requireRowAffected(result, ErrRoomNotFound)
+}
+
+// DeleteRoom removes a room record together with its calendar, booking
+// history and reservations in a single transaction.
+// Parameters:
+//   - roomID string: Room identifier
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record, or any error encountered
+func (r *SQLRoomRepository) DeleteRoom(roomID string) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		for _, table := range []string{"reservations", "room_booking_history", "room_bookings"} {
+			if _, err := tx.Exec(r.rebind(`DELETE FROM `+table+` WHERE room_id = $1`), roomID); err != nil {
+				return fmt.Errorf("error deleting from %s: %v", table, err)
+			}
+		}
+
+		result, err := tx.Exec(r.rebind(`DELETE FROM rooms WHERE room_id = $1`), roomID)
+		if err != nil {
+			return fmt.Errorf("error deleting room: %v", err)
+		}
+		return requireRowAffected(result, ErrRoomNotFound)
+	})
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRoom reads a room selected with roomColumns.
+// Parameters:
+//   - row rowScanner: Row positioned on a room
+//
+// Returns:
+//   - models.Room: The scanned room
+//   - error: sql.ErrNoRows if there is no row, or any error encountered while scanning
+func scanRoom(row rowScanner, extra ...interface{}) (models.Room, error) {
+	var room models.Room
+	var latitude, longitude sql.NullFloat64
+	dest := append([]interface{}{&room.RoomID, &room.Name, &room.City, &room.Neighborhood, &room.PropertyType,
+		&room.Bedrooms, &room.Capacity, &latitude, &longitude, &room.Currency, &room.TimeZone}, extra...)
+	err := row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return room, err
+	}
+	if err != nil {
+		return room, fmt.Errorf("error scanning room: %v", err)
+	}
+
+	if latitude.Valid {
+		room.Latitude = &latitude.Float64
+	}
+	if longitude.Valid {
+		room.Longitude = &longitude.Float64
+	}
+	room.Currency = strings.TrimSpace(room.Currency)
+
+	return room, nil
+}
+
+// requireRowAffected checks that a statement changed at least one row.
+// Parameters:
+//   - result sql.Result: Result of the statement
+//   - errNone error: Error to return if no row was changed
+//
+// Returns:
+//   - error: errNone if no row was changed, or any error reading the result
+func requireRowAffected(result sql.Result, errNone error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %v", err)
+	}
+	if affected == 0 {
+		return errNone
+	}
+	return nil
+}
+
+// GetReservations retrieves the reservations of a room checking in within a date range.
+// Parameters:
+//   - roomID string: Room identifier
+//   - startDate time.Time: First check-in day to include
+//   - endDate time.Time: Last check-in day to include
+//
+// Returns:
+//   - []models.Reservation: Reservations ordered by check-in
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetReservations(roomID string, startDate, endDate time.Time) (reservations []models.Reservation, err error) {
+	query := `
+        SELECT id, check_in, check_out, booked_at, guest_count, total_price, channel
+        FROM reservations
+        WHERE room_id = $1
+        AND check_in >= $2
+        AND check_in <= $3
+        ORDER BY check_in, id
+    `
+
+	rows, err := r.db.Query(r.rebind(query), roomID, formatDate(startDate), formatDate(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("error querying reservations: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		reservation := models.Reservation{RoomID: roomID}
+		var checkIn, checkOut dateValue
+		if err := rows.Scan(&reservation.ID, &checkIn, &checkOut, &reservation.BookedAt,
+			&reservation.GuestCount, &reservation.TotalPrice, &reservation.Channel); err != nil {
+			return nil, fmt.Errorf("error scanning reservation: %v", err)
+		}
+		reservation.CheckIn = string(checkIn)
+		reservation.CheckOut = string(checkOut)
+		reservation.BookedAt = reservation.BookedAt.UTC()
+		reservations = append(reservations, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reservations: %v", err)
+	}
+
+	return reservations, nil
+}
+
+// CreateReservation stores a reservation and marks its nights booked in a
+// single transaction. Existing days are overwritten with the given nights.
+// On PostgreSQL the room's record is locked first so that concurrent
+// reservations of a room are checked for overlaps one after another; SQLite
+// serializes writers on its own.
+// Parameters:
+//   - reservation models.Reservation: Reservation to store, its ID is ignored
+//   - nights []models.RoomBooking: Calendar days of the stay
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - models.Reservation: The stored reservation with its assigned ID
+//   - error: ErrReservationOverlap if the stay overlaps another reservation, or any error encountered
+func (r *SQLRoomRepository) CreateReservation(reservation models.Reservation, nights []models.RoomBooking, changedAt time.Time) (models.Reservation, error) {
+	overlapQuery := `
+        SELECT COUNT(*)
+        FROM reservations
+        WHERE room_id = $1
+        AND check_in < $3
+        AND check_out > $2
+    `
+	insertQuery := `
+        INSERT INTO reservations (room_id, check_in, check_out, booked_at, guest_count, total_price, channel)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+
+	lockQuery := `SELECT room_id FROM rooms WHERE room_id = $1 FOR UPDATE`
+
+	err := r.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(r.rebind(registerRoomQuery), reservation.RoomID); err != nil {
+			return fmt.Errorf("error registering room %s: %v", reservation.RoomID, err)
+		}
+		if r.dialect == DialectPostgres {
+			if _, err := tx.Exec(lockQuery, reservation.RoomID); err != nil {
+				return fmt.Errorf("error locking room %s: %v", reservation.RoomID, err)
+			}
+		}
+
+		var overlapping int
+		if err := tx.QueryRow(r.rebind(overlapQuery), reservation.RoomID, reservation.CheckIn, reservation.CheckOut).Scan(&overlapping); err != nil {
+			return fmt.Errorf("error checking for overlapping reservations: %v", err)
+		}
+		if overlapping > 0 {
+			return ErrReservationOverlap
+		}
+
+		if err := tx.QueryRow(r.rebind(insertQuery), reservation.RoomID, reservation.CheckIn, reservation.CheckOut,
+			reservation.BookedAt, reservation.GuestCount, reservation.TotalPrice, reservation.Channel).Scan(&reservation.ID); err != nil {
+			return fmt.Errorf("error inserting reservation: %v", err)
+		}
+
+		return r.execBookingsTx(tx, upsertBookingsQuery, nights, changedAt)
+	})
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// GetExchangeRates retrieves the exchange rates of several currencies for a
+// given date range with a single query.
+// Parameters:
+//   - currencies []string: ISO 4217 codes of the currencies to include
+//   - startDate time.Time: Start of date range
+//   - endDate time.Time: End of date range
+//
+// Returns:
+//   - []models.ExchangeRate: Exchange rates ordered by currency and date
+//   - error: Any error encountered
+func (r *SQLRoomRepository) GetExchangeRates(currencies []string, startDate, endDate time.Time) (rates []models.ExchangeRate, err error) {
+	if len(currencies) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{formatDate(startDate), formatDate(endDate)}
+	placeholders := make([]string, len(currencies))
+	for i, currency := range currencies {
+		args = append(args, currency)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+	query := `
+        SELECT currency, date, rate
+        FROM exchange_rates
+        WHERE date >= $1
+        AND date <= $2
+        AND currency IN (` + strings.Join(placeholders, ", ") + `)
+        ORDER BY currency, date
+    `
+
+	rows, err := r.db.Query(r.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying exchange rates: %v", err)
+	}
+
+	// Using named return to handle close error
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing rows: %v", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var rate models.ExchangeRate
+		var date dateValue
+		if err := rows.Scan(&rate.Currency, &date, &rate.Rate); err != nil {
+			return nil, fmt.Errorf("error scanning exchange rate: %v", err)
+		}
+		rate.Currency = strings.TrimSpace(rate.Currency)
+		rate.Date = string(date)
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exchange rates: %v", err)
+	}
+
+	return rates, nil
+}
+
+// UpsertExchangeRates inserts the given exchange rates, replacing the rates of
+// currencies and days that already exist. All rows are written in a single transaction.
+// Parameters:
+//   - rates []models.ExchangeRate: Daily exchange rates to write
+//
+// Returns:
+//   - error: Any error encountered, in which case no rows are written
+func (r *SQLRoomRepository) UpsertExchangeRates(rates []models.ExchangeRate) error {
+	query := `
+        INSERT INTO exchange_rates (currency, date, rate)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (currency, date)
+        DO UPDATE SET rate = excluded.rate
+    `
+
+	return r.inTx(func(tx *sql.Tx) (err error) {
+		stmt, err := tx.Prepare(r.rebind(query))
+		if err != nil {
+			return fmt.Errorf("error preparing upsert: %v", err)
+		}
+		defer func() {
+			if closeErr := stmt.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("error closing statement: %v", closeErr)
+			}
+		}()
+
+		for _, rate := range rates {
+			if _, err = stmt.Exec(rate.Currency, rate.Date, rate.Rate); err != nil {
+				return fmt.Errorf("error upserting %s exchange rate on %s: %v", rate.Currency, rate.Date, err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertBookings inserts the given bookings, replacing the booking status and
+// rate of days that already exist. All rows are written in a single transaction.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily bookings to write
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - error: Any error encountered, in which case no rows are written
+func (r *SQLRoomRepository) UpsertBookings(bookings []models.RoomBooking, changedAt time.Time) error {
+	return r.execBookings(upsertBookingsQuery, bookings, changedAt)
+}
+
+// UpsertBookingStatus updates the booking status of the given days, keeping
+// the rates of days that already exist. Days that don't exist yet are inserted
+// with the booking's rate. All rows are written in a single transaction.
+// Parameters:
+//   - bookings []models.RoomBooking: Daily booking statuses to write
+//   - changedAt time.Time: Time recorded in the booking history
+//
+// Returns:
+//   - error: Any error encountered, in which case no rows are written
+func (r *SQLRoomRepository) UpsertBookingStatus(bookings []models.RoomBooking, changedAt time.Time) error {
+	query := `
+        INSERT INTO room_bookings (room_id, date, is_booked, rate, changed_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (room_id, date)
+        DO UPDATE SET is_booked = excluded.is_booked, changed_at = excluded.changed_at
+    `
+	return r.execBookings(query, bookings, changedAt)
+}
+
+// execBookings executes an insert statement once per booking inside a transaction.
+// The statement receives room_id, date, is_booked, rate and the change time as $1 to $5.
+// Parameters:
+//   - query string: Insert statement to execute
+//   - bookings []models.RoomBooking: Bookings providing the statement arguments
+//   - changedAt time.Time: Time of the change
+//
+// Returns:
+//   - error: Any error encountered, in which case the transaction is rolled back
+func (r *SQLRoomRepository) execBookings(query string, bookings []models.RoomBooking, changedAt time.Time) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		return r.execBookingsTx(tx, query, bookings, changedAt)
+	})
+}
+
+// execBookingsTx executes an insert statement once per booking within a transaction.
+// Rooms without a record are registered with default attributes first.
+// Parameters:
+//   - tx *sql.Tx: Transaction to execute in
+//   - query string: Insert statement receiving room_id, date, is_booked, rate and the change time
+//   - bookings []models.RoomBooking: Bookings providing the statement arguments
+//   - changedAt time.Time: Time of the change
+//
+// Returns:
+//   - error: Any error encountered
+func (r *SQLRoomRepository) execBookingsTx(tx *sql.Tx, query string, bookings []models.RoomBooking, changedAt time.Time) (err error) {
+	registered := make(map[string]bool)
+	for _, booking := range bookings {
+		if registered[booking.RoomID] {
+			continue
+		}
+		if _, err = tx.Exec(r.rebind(registerRoomQuery), booking.RoomID); err != nil {
+			return fmt.Errorf("error registering room %s: %v", booking.RoomID, err)
+		}
+		registered[booking.RoomID] = true
+	}
+
+	stmt, err := tx.Prepare(r.rebind(query))
+	if err != nil {
+		return fmt.Errorf("error preparing upsert: %v", err)
+	}
+	defer func() {
+		if closeErr := stmt.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing statement: %v", closeErr)
+		}
+	}()
+
+	for _, booking := range bookings {
+		if _, err = stmt.Exec(booking.RoomID, booking.Date, booking.IsBooked, booking.Rate, changedAt.UTC()); err != nil {
+			return fmt.Errorf("error upserting room %s on %s: %v", booking.RoomID, booking.Date, err)
+		}
+	}
+
+	return nil
+}
+
+// inTx runs fn inside a transaction, committing if it succeeds.
+// Parameters:
+//   - fn func(*sql.Tx) error: Work to perform within the transaction
+//
+// Returns:
+//   - error: Any error encountered, in which case the transaction is rolled back
+func (r *SQLRoomRepository) inTx(fn func(*sql.Tx) error) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+
+	// Roll back on any error, commit otherwise
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				err = fmt.Errorf("%v (rollback failed: %v)", err, rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("error committing transaction: %v", commitErr)
+		}
+	}()
+
+	return fn(tx)
+}
+
+// formatDate formats a time as a "YYYY-MM-DD" query parameter.
+// Parameters:
+//   - t time.Time: Time to format
+//
+// Returns:
+//   - string: Calendar date of t
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// dateValue scans a DATE column into a "YYYY-MM-DD" string.
+// PostgreSQL returns dates as time.Time while SQLite may return text.
+type dateValue string
+
+// Scan implements sql.Scanner.
+func (d *dateValue) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = dateValue(v.Format("2006-01-02"))
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	default:
+		return fmt.Errorf("unsupported date type %T", src)
+	}
+	return nil
+}
+
+// parse normalizes a textual date, accepting timestamps with a time component.
+func (d *dateValue) parse(s string) error {
+	if len(s) < 10 {
+		return fmt.Errorf("invalid date %q", s)
+	}
+	date, err := time.Parse("2006-01-02", s[:10])
+	if err != nil {
+		return fmt.Errorf("invalid date %q", s)
+	}
+	*d = dateValue(date.Format("2006-01-02"))
+	return nil
+}
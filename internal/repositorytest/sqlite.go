@@ -0,0 +1,37 @@
+// Package repositorytest provides databases for tests that run against the
+// storage backends.
+package repositorytest
+
+import (
+	"airbnb-analytics/internal/database"
+	"airbnb-analytics/internal/migrations"
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+// NewSQLiteDB opens a SQLite database in a temporary file migrated to the
+// latest schema. The database is closed when the test ends.
+// Parameters:
+//   - t testing.TB: Test the database belongs to
+//
+// Returns:
+//   - *sql.DB: Open, empty database
+func NewSQLiteDB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.New(db, "sqlite")
+	if err != nil {
+		t.Fatalf("creating migrator: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("migrating SQLite database: %v", err)
+	}
+	return db
+}
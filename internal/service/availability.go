@@ -0,0 +1,73 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"time"
+)
+
+// FindAvailability finds the rooms that can host a stay: every night from
+// check-in up to but excluding check-out must be known and unbooked, and with
+// a positive maxRate none of them may be priced above it.
+// Parameters:
+//   - checkIn time.Time: First night of the stay
+//   - checkOut time.Time: Departure day, not a night of the stay
+//   - maxRate float64: Highest nightly rate allowed, 0 for no limit
+//
+// Returns:
+//   - *models.Availability: Available rooms with the total and nightly rate of the stay
+//   - error: ErrInvalidWindow or ErrInvalidOptions wrapped with details, or any storage error
+func (s *RoomService) FindAvailability(checkIn, checkOut time.Time, maxRate float64) (*models.Availability, error) {
+	checkIn, checkOut, nights, err := validateStay(checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	if maxRate < 0 {
+		return nil, fmt.Errorf("%w: max_rate must not be negative", ErrInvalidOptions)
+	}
+
+	rooms, err := s.repo.FindAvailableRooms(checkIn, checkOut.AddDate(0, 0, -1), maxRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch available rooms: %v", err)
+	}
+
+	availability := &models.Availability{
+		CheckIn:  checkIn.Format(dateLayout),
+		CheckOut: checkOut.Format(dateLayout),
+		Nights:   nights,
+		Rooms:    make([]models.AvailableRoom, 0, len(rooms)),
+	}
+	for _, room := range rooms {
+		room.NightlyRate = round(room.TotalRate / float64(nights))
+		room.TotalRate = round(room.TotalRate)
+		availability.Rooms = append(availability.Rooms, room)
+	}
+
+	return availability, nil
+}
+
+// validateStay checks the check-in and check-out days of a stay.
+// Parameters:
+//   - checkIn time.Time: First night of the stay
+//   - checkOut time.Time: Departure day, not a night of the stay
+//
+// Returns:
+//   - time.Time: Check-in truncated to the day
+//   - time.Time: Check-out truncated to the day
+//   - int: Number of nights of the stay
+//   - error: ErrInvalidWindow wrapped with details if the stay is invalid
+func validateStay(checkIn, checkOut time.Time) (time.Time, time.Time, int, error) {
+	if checkIn.IsZero() || checkOut.IsZero() {
+		return checkIn, checkOut, 0, fmt.Errorf("%w: check_in and check_out are required", ErrInvalidWindow)
+	}
+	checkIn, checkOut = truncateToDay(checkIn), truncateToDay(checkOut)
+
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+	if nights < 1 {
+		return checkIn, checkOut, 0, fmt.Errorf("%w: check_out must be after check_in", ErrInvalidWindow)
+	}
+	if nights > maxReservationNights {
+		return checkIn, checkOut, 0, fmt.Errorf("%w: a stay can be at most %d nights", ErrInvalidWindow, maxReservationNights)
+	}
+	return checkIn, checkOut, nights, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repository"
+	"airbnb-analytics/internal/repositorytest"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindAvailability(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name              string
+		checkIn, checkOut time.Time
+		want              []string
+		wantNightly       float64
+		wantErr           error
+	}{
+		{
+			name:        "the check-out day is not a night of the stay",
+			checkIn:     day(1),
+			checkOut:    day(4),
+			want:        []string{"CHECKOUT_BOOKED", "FREE"},
+			wantNightly: 100,
+		},
+		{
+			name:        "the night before check-out is",
+			checkIn:     day(1),
+			checkOut:    day(5),
+			want:        []string{"FREE"},
+			wantNightly: 100,
+		},
+		{
+			name:     "check-out must follow check-in",
+			checkIn:  day(3),
+			checkOut: day(3),
+			wantErr:  ErrInvalidWindow,
+		},
+		{
+			name:     "stays are limited in length",
+			checkIn:  day(1),
+			checkOut: day(1).AddDate(0, 0, maxReservationNights+1),
+			wantErr:  ErrInvalidWindow,
+		},
+	}
+
+	for name, repo := range map[string]repository.RoomRepository{
+		"memory": repository.NewMemoryRoomRepository(),
+		"sqlite": repository.NewSQLiteRoomRepository(repositorytest.NewSQLiteDB(t)),
+	} {
+		// FREE is open on 1-5 March, CHECKOUT_BOOKED is booked on 4 March
+		var bookings []models.RoomBooking
+		for d := 1; d <= 5; d++ {
+			date := day(d).Format(dateLayout)
+			bookings = append(bookings,
+				models.RoomBooking{RoomID: "FREE", RoomData: models.RoomData{Date: date, Rate: 100}},
+				models.RoomBooking{RoomID: "CHECKOUT_BOOKED", RoomData: models.RoomData{Date: date, IsBooked: d == 4, Rate: 100}},
+			)
+		}
+		if err := repo.UpsertBookings(bookings, time.Now()); err != nil {
+			t.Fatalf("seeding bookings: %v", err)
+		}
+		s := NewRoomService(repo)
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				availability, err := s.FindAvailability(tt.checkIn, tt.checkOut, 0)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("FindAvailability() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("FindAvailability() error = %v", err)
+				}
+
+				var got []string
+				for _, room := range availability.Rooms {
+					got = append(got, room.RoomID)
+					if room.NightlyRate != tt.wantNightly {
+						t.Errorf("NightlyRate of %s = %v, want %v", room.RoomID, room.NightlyRate, tt.wantNightly)
+					}
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("rooms = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// maxCalendarDays limits the number of days a single calendar update may set
+const maxCalendarDays = 366
+
+// ErrInvalidCalendar is returned when a calendar update fails validation
+var ErrInvalidCalendar = errors.New("invalid calendar update")
+
+// UpdateCalendar sets booking status and rate for a list of days or a date
+// range of a room. Days are upserted in a single transaction.
+// Parameters:
+//   - roomID string: Room to update
+//   - update models.CalendarUpdate: Days or range to set
+//
+// Returns:
+//   - *models.CalendarResponse: The days as written
+//   - error: ErrInvalidCalendar wrapped with details, or any storage error
+func (s *RoomService) UpdateCalendar(roomID string, update models.CalendarUpdate) (*models.CalendarResponse, error) {
+	if err := validateRoomID(roomID); err != nil {
+		return nil, err
+	}
+
+	days, err := calendarDays(update)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings := make([]models.RoomBooking, 0, len(days))
+	for _, day := range days {
+		bookings = append(bookings, models.RoomBooking{RoomID: roomID, RoomData: day})
+	}
+
+	if err := s.repo.UpsertBookings(bookings, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("failed to update calendar: %v", err)
+	}
+
+	return &models.CalendarResponse{
+		RoomID: roomID,
+		Days:   days,
+	}, nil
+}
+
+// PatchCalendarDay partially updates a single day of a room. Fields that are
+// not set keep their current value; a day that doesn't exist yet requires both.
+// Parameters:
+//   - roomID string: Room to update
+//   - date string: Day to update in "YYYY-MM-DD" format
+//   - patch models.CalendarDayPatch: Fields to change
+//
+// Returns:
+//   - *models.RoomData: The day as written
+//   - error: ErrInvalidCalendar wrapped with details, or any storage error
+func (s *RoomService) PatchCalendarDay(roomID, date string, patch models.CalendarDayPatch) (*models.RoomData, error) {
+	if err := validateRoomID(roomID); err != nil {
+		return nil, err
+	}
+
+	day, err := parseCalendarDate("date", date)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.IsBooked == nil && patch.Rate == nil {
+		return nil, fmt.Errorf("%w: is_booked or rate is required", ErrInvalidCalendar)
+	}
+	if patch.Rate != nil {
+		if err := validateRate(*patch.Rate); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.repo.GetRoomData(roomID, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room data: %v", err)
+	}
+
+	var result models.RoomData
+	if len(existing) > 0 {
+		result = existing[0]
+	} else if patch.IsBooked == nil || patch.Rate == nil {
+		return nil, fmt.Errorf("%w: day %s does not exist, is_booked and rate are required", ErrInvalidCalendar, date)
+	}
+
+	result.Date = day.Format(dateLayout)
+	if patch.IsBooked != nil {
+		result.IsBooked = *patch.IsBooked
+	}
+	if patch.Rate != nil {
+		result.Rate = *patch.Rate
+	}
+
+	if err := s.repo.UpsertBookings([]models.RoomBooking{{RoomID: roomID, RoomData: result}}, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("failed to update calendar: %v", err)
+	}
+
+	return &result, nil
+}
+
+// calendarDays validates an update and expands it into individual days.
+// Parameters:
+//   - update models.CalendarUpdate: Days or range to set
+//
+// Returns:
+//   - []models.RoomData: Validated days with normalized dates
+//   - error: ErrInvalidCalendar wrapped with details
+func calendarDays(update models.CalendarUpdate) ([]models.RoomData, error) {
+	hasRange := update.From != "" || update.To != "" || update.IsBooked != nil || update.Rate != nil
+	if len(update.Days) > 0 && hasRange {
+		return nil, fmt.Errorf("%w: days cannot be combined with a date range", ErrInvalidCalendar)
+	}
+
+	if len(update.Days) > 0 {
+		if len(update.Days) > maxCalendarDays {
+			return nil, fmt.Errorf("%w: at most %d days can be set at once", ErrInvalidCalendar, maxCalendarDays)
+		}
+
+		seen := make(map[string]bool)
+		days := make([]models.RoomData, 0, len(update.Days))
+		for _, input := range update.Days {
+			date, err := parseCalendarDate("date", input.Date)
+			if err != nil {
+				return nil, err
+			}
+			day := models.RoomData{Date: date.Format(dateLayout)}
+			if input.IsBooked == nil || input.Rate == nil {
+				return nil, fmt.Errorf("%w: is_booked and rate are required for day %s", ErrInvalidCalendar, day.Date)
+			}
+			if err := validateRate(*input.Rate); err != nil {
+				return nil, err
+			}
+			day.IsBooked, day.Rate = *input.IsBooked, *input.Rate
+			if seen[day.Date] {
+				return nil, fmt.Errorf("%w: duplicate day %s", ErrInvalidCalendar, day.Date)
+			}
+			seen[day.Date] = true
+			days = append(days, day)
+		}
+		return days, nil
+	}
+
+	if update.IsBooked == nil || update.Rate == nil {
+		return nil, fmt.Errorf("%w: either days or from, to, is_booked and rate are required", ErrInvalidCalendar)
+	}
+
+	from, err := parseCalendarDate("from", update.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseCalendarDate("to", update.To)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", ErrInvalidCalendar)
+	}
+	if to.After(from.AddDate(0, 0, maxCalendarDays-1)) {
+		return nil, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidCalendar, maxCalendarDays)
+	}
+	if err := validateRate(*update.Rate); err != nil {
+		return nil, err
+	}
+
+	var days []models.RoomData
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, models.RoomData{
+			Date:     day.Format(dateLayout),
+			IsBooked: *update.IsBooked,
+			Rate:     *update.Rate,
+		})
+	}
+	return days, nil
+}
+
+// validateRoomID checks that a room ID fits the room_id column.
+// Parameters:
+//   - roomID string: Room identifier
+//
+// Returns:
+//   - error: ErrInvalidCalendar wrapped with details if invalid
+func validateRoomID(roomID string) error {
+	if strings.TrimSpace(roomID) == "" {
+		return fmt.Errorf("%w: room ID is required", ErrInvalidCalendar)
+	}
+	if len(roomID) > models.MaxRoomIDLength {
+		return fmt.Errorf("%w: room ID must be at most %d characters", ErrInvalidCalendar, models.MaxRoomIDLength)
+	}
+	return nil
+}
+
+// validateRate checks that a rate is non-negative and fits the rate column.
+// Parameters:
+//   - rate float64: Rate to check
+//
+// Returns:
+//   - error: ErrInvalidCalendar wrapped with details if invalid
+func validateRate(rate float64) error {
+	if math.IsNaN(rate) || rate < 0 || rate > models.MaxRate {
+		return fmt.Errorf("%w: rate must be between 0 and %.2f", ErrInvalidCalendar, models.MaxRate)
+	}
+	return nil
+}
+
+// parseCalendarDate parses a "YYYY-MM-DD" date from a calendar update.
+// Parameters:
+//   - name string: Field name used in error messages
+//   - value string: Date to parse
+//
+// Returns:
+//   - time.Time: Parsed date at midnight UTC
+//   - error: ErrInvalidCalendar wrapped with details if invalid
+func parseCalendarDate(name, value string) (time.Time, error) {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be a date in YYYY-MM-DD format", ErrInvalidCalendar, name)
+	}
+	return date, nil
+}
+
+const (
+	// defaultCalendarDays is the range returned when no end date is requested
+	defaultCalendarDays = 30
+	// maxCalendarPageDays is the largest number of days returned per page
+	maxCalendarPageDays = 92
+)
+
+// CalendarOptions controls the range and presentation of a raw calendar request.
+type CalendarOptions struct {
+	// From is the first day to return, defaults to today
+	From time.Time
+	// To is the last day to return, defaults to 30 days from From
+	To time.Time
+	// FillUnknown adds days without stored data with status "unknown"
+	FillUnknown bool
+}
+
+// GetCalendar retrieves the raw daily calendar of a room. Ranges longer than
+// maxCalendarPageDays are paginated; the response names the start of the next page.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts CalendarOptions: Requested range and fill behaviour
+//
+// Returns:
+//   - *models.CalendarPage: Calendar days of the requested page
+//   - error: ErrInvalidWindow wrapped with details, or any storage error
+func (s *RoomService) GetCalendar(roomID string, opts CalendarOptions) (*models.CalendarPage, error) {
+	from, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+
+	to := from.AddDate(0, 0, defaultCalendarDays-1)
+	if !opts.To.IsZero() {
+		to = truncateToDay(opts.To)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", ErrInvalidWindow)
+	}
+
+	page := &models.CalendarPage{RoomID: roomID}
+	if pageEnd := from.AddDate(0, 0, maxCalendarPageDays-1); to.After(pageEnd) {
+		page.NextFrom = pageEnd.AddDate(0, 0, 1).Format(dateLayout)
+		to = pageEnd
+	}
+	page.From = from.Format(dateLayout)
+	page.To = to.Format(dateLayout)
+
+	roomData, err := s.repo.GetRoomData(roomID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room data: %v", err)
+	}
+
+	byDate := make(map[string]models.RoomData, len(roomData))
+	for _, day := range roomData {
+		byDate[day.Date] = day
+	}
+
+	page.Days = []models.CalendarDay{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		data, ok := byDate[date]
+		switch {
+		case ok:
+			page.Days = append(page.Days, calendarDay(data))
+		case opts.FillUnknown:
+			page.Days = append(page.Days, models.CalendarDay{Date: date, Status: models.DayStatusUnknown})
+		}
+	}
+
+	return page, nil
+}
+
+// calendarDay converts stored room data into a calendar day with a status.
+// Parameters:
+//   - data models.RoomData: Stored booking data for a day
+//
+// Returns:
+//   - models.CalendarDay: Calendar day with status "booked" or "available"
+func calendarDay(data models.RoomData) models.CalendarDay {
+	status := models.DayStatusAvailable
+	if data.IsBooked {
+		status = models.DayStatusBooked
+	}
+	return models.CalendarDay{
+		Date:     data.Date,
+		Status:   status,
+		IsBooked: &data.IsBooked,
+		Rate:     &data.Rate,
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import "time"
+
+// Clock provides the current time to the service layer.
+// Injecting a Clock makes analytics reproducible and testable.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
+
+// systemClock is the default Clock backed by the wall clock.
+type systemClock struct{}
+
+// Now returns the current wall-clock time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock that always reports the same instant.
+// It is useful for backtesting and deterministic reports.
+type FixedClock time.Time
+
+// Now returns the fixed instant.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// Option configures optional dependencies of a RoomService.
+type Option func(*RoomService)
+
+// WithClock sets the clock used to determine "today".
+// Parameters:
+//   - clock Clock: Clock implementation to use
+//
+// Returns:
+//   - Option: Option applying the clock
+func WithClock(clock Clock) Option {
+	return func(s *RoomService) {
+		s.clock = clock
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// minCompareRooms is the smallest number of rooms that can be compared
+	minCompareRooms = 2
+	// maxCompareRooms limits the number of rooms compared per request
+	maxCompareRooms = 20
+)
+
+// CompareOptions controls the rooms and date ranges used by CompareRooms.
+type CompareOptions struct {
+	AnalyticsOptions
+	// RoomIDs lists the rooms to compare
+	RoomIDs []string
+}
+
+// CompareRooms computes occupancy and rate analytics for several rooms over
+// the same windows from a single repository query, aligned by month, along
+// with each room's difference to the group average. The group average weighs
+// every room equally. With opts.Currency rates are converted to a common
+// currency first; without it all rooms must share one currency.
+// Parameters:
+//   - opts CompareOptions: Rooms and analysis windows, zero values select defaults
+//
+// Returns:
+//   - *models.RoomComparison: Aligned analytics per room and group averages
+//   - error: ErrInvalidOptions, also for rooms in different currencies without
+//     opts.Currency, ErrInvalidWindow, ErrRoomNotFound or ErrMissingExchangeRate
+//     wrapped with details, or any error encountered during data retrieval
+func (s *RoomService) CompareRooms(opts CompareOptions) (*models.RoomComparison, error) {
+	roomIDs := uniqueRoomIDs(opts.RoomIDs)
+	if len(roomIDs) < minCompareRooms || len(roomIDs) > maxCompareRooms {
+		return nil, fmt.Errorf("%w: between %d and %d distinct rooms can be compared",
+			ErrInvalidOptions, minCompareRooms, maxCompareRooms)
+	}
+
+	if opts.Compare != "" {
+		return nil, fmt.Errorf("%w: compare is not supported when comparing rooms", ErrInvalidOptions)
+	}
+
+	asOf := s.today()
+	if !opts.AsOf.IsZero() {
+		asOf = truncateToDay(opts.AsOf)
+	}
+
+	window, err := resolveWindow(opts.AnalyticsOptions, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	percentiles, err := resolvePercentiles(opts.Percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := resolveCurrency(opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate := window.fetchRange()
+	bookings, err := s.repo.GetBookings(roomIDs, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings: %v", err)
+	}
+
+	if currency != "" {
+		if err := s.convertBookings(bookings, currency, startDate, endDate); err != nil {
+			return nil, err
+		}
+	} else if currency, err = s.commonCurrency(bookings); err != nil {
+		return nil, err
+	}
+
+	byRoom := make(map[string][]models.RoomData)
+	for _, booking := range bookings {
+		byRoom[booking.RoomID] = append(byRoom[booking.RoomID], booking.RoomData)
+	}
+
+	var missing []string
+	for _, roomID := range roomIDs {
+		if len(byRoom[roomID]) == 0 {
+			missing = append(missing, roomID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrRoomNotFound, strings.Join(missing, ", "))
+	}
+
+	var months []string
+	for month := monthStart(window.occupancyStart); !month.After(window.occupancyEnd); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format("2006-01"))
+	}
+
+	comparison := &models.RoomComparison{
+		Currency: currency,
+		Window:   window.toModel(),
+		Months:   months,
+		Rooms:    make([]models.ComparedRoom, 0, len(roomIDs)),
+	}
+
+	// monthly holds each room's occupancy aligned to months, nil without data
+	monthly := make([][]*float64, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		data := byRoom[roomID]
+		rates := calculateRateAnalytics(data, window.rateStart, window.rateEnd, percentiles)
+
+		occupancy := make(map[string]float64)
+		for _, month := range calculateMonthlyOccupancy(data, window.occupancyStart, window.occupancyEnd) {
+			occupancy[month.Month] = month.OccupancyPercentage
+		}
+		aligned := make([]*float64, len(months))
+		for i, month := range months {
+			if value, ok := occupancy[month]; ok {
+				aligned[i] = &value
+			}
+		}
+		monthly = append(monthly, aligned)
+
+		comparison.Rooms = append(comparison.Rooms, models.ComparedRoom{
+			RoomID: roomID,
+			Metrics: models.ComparisonMetrics{
+				OccupancyPercentage: occupancyPercentage(data, window.occupancyStart, window.occupancyEnd),
+				AverageRate:         rates.AverageRate,
+				ADR:                 rates.ADR,
+				RevPAR:              rates.RevPAR,
+			},
+			RateAnalytics: rates,
+		})
+	}
+
+	comparison.GroupAverage = averageMetrics(comparison.Rooms)
+	groupMonthly := averageMonthly(monthly, len(months))
+
+	for i := range comparison.Rooms {
+		room := &comparison.Rooms[i]
+		room.Deltas = models.ComparisonMetrics{
+			OccupancyPercentage: round(room.Metrics.OccupancyPercentage - comparison.GroupAverage.OccupancyPercentage),
+			AverageRate:         round(room.Metrics.AverageRate - comparison.GroupAverage.AverageRate),
+			ADR:                 round(room.Metrics.ADR - comparison.GroupAverage.ADR),
+			RevPAR:              round(room.Metrics.RevPAR - comparison.GroupAverage.RevPAR),
+		}
+
+		room.MonthlyOccupancy = make([]models.ComparedMonth, 0, len(months))
+		for j, month := range months {
+			compared := models.ComparedMonth{
+				Month:               month,
+				OccupancyPercentage: monthly[i][j],
+				GroupAverage:        groupMonthly[j],
+			}
+			if monthly[i][j] != nil && groupMonthly[j] != nil {
+				delta := round(*monthly[i][j] - *groupMonthly[j])
+				compared.Delta = &delta
+			}
+			room.MonthlyOccupancy = append(room.MonthlyOccupancy, compared)
+		}
+	}
+
+	return comparison, nil
+}
+
+// uniqueRoomIDs removes duplicate and blank room IDs, keeping the first occurrence.
+// Parameters:
+//   - roomIDs []string: Requested room IDs
+//
+// Returns:
+//   - []string: Distinct room IDs in request order
+func uniqueRoomIDs(roomIDs []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, roomID := range roomIDs {
+		if roomID = strings.TrimSpace(roomID); roomID != "" && !seen[roomID] {
+			seen[roomID] = true
+			unique = append(unique, roomID)
+		}
+	}
+	return unique
+}
+
+// averageMetrics calculates the equally weighted average metrics of rooms.
+// Parameters:
+//   - rooms []models.ComparedRoom: Rooms with their metrics, must not be empty
+//
+// Returns:
+//   - models.ComparisonMetrics: Average of each metric
+func averageMetrics(rooms []models.ComparedRoom) models.ComparisonMetrics {
+	var sum models.ComparisonMetrics
+	for _, room := range rooms {
+		sum.OccupancyPercentage += room.Metrics.OccupancyPercentage
+		sum.AverageRate += room.Metrics.AverageRate
+		sum.ADR += room.Metrics.ADR
+		sum.RevPAR += room.Metrics.RevPAR
+	}
+
+	n := float64(len(rooms))
+	return models.ComparisonMetrics{
+		OccupancyPercentage: round(sum.OccupancyPercentage / n),
+		AverageRate:         round(sum.AverageRate / n),
+		ADR:                 round(sum.ADR / n),
+		RevPAR:              round(sum.RevPAR / n),
+	}
+}
+
+// averageMonthly calculates the average occupancy per month over the rooms
+// with data in that month.
+// Parameters:
+//   - monthly [][]*float64: Occupancy per room aligned to months, nil without data
+//   - months int: Number of aligned months
+//
+// Returns:
+//   - []*float64: Average per month, nil if no room has data
+func averageMonthly(monthly [][]*float64, months int) []*float64 {
+	averages := make([]*float64, months)
+	for j := 0; j < months; j++ {
+		var sum float64
+		var count int
+		for _, room := range monthly {
+			if room[j] != nil {
+				sum += *room[j]
+				count++
+			}
+		}
+		if count > 0 {
+			average := round(sum / float64(count))
+			averages[j] = &average
+		}
+	}
+	return averages
+}
+
+// monthStart returns the first day of the month containing t.
+// Parameters:
+//   - t time.Time: Any day of the month
+//
+// Returns:
+//   - time.Time: Midnight UTC of the first day of the month
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"time"
+)
+
+const (
+	// ComparePrevious compares each month with the month before and the rate
+	// window with the window of the same length directly before it
+	ComparePrevious = "previous"
+	// CompareLastYear compares each month and the rate window with the same
+	// period one year earlier
+	CompareLastYear = "last_year"
+)
+
+// comparisonWindow shifts the window back to its comparison period.
+// Returns:
+//   - analysisWindow: Window covering the comparison period
+func (w analysisWindow) comparisonWindow() analysisWindow {
+	shifted := w
+	switch w.compare {
+	case ComparePrevious:
+		shifted.occupancyStart = addMonths(w.occupancyStart, -1)
+		shifted.occupancyEnd = addMonths(w.occupancyEnd, -1)
+		shifted.rateStart = w.rateStart.AddDate(0, 0, -w.rateDays)
+		shifted.rateEnd = w.rateStart.AddDate(0, 0, -1)
+	case CompareLastYear:
+		shifted.occupancyStart = addMonths(w.occupancyStart, -12)
+		shifted.occupancyEnd = addMonths(w.occupancyEnd, -12)
+		shifted.rateStart = addMonths(w.rateStart, -12)
+		shifted.rateEnd = addMonths(w.rateEnd, -12)
+	}
+	return shifted
+}
+
+// comparisonMonths returns the number of months a comparison shifts monthly entries.
+// Returns:
+//   - int: Months between a month and its comparison month
+func (w analysisWindow) comparisonMonths() int {
+	if w.compare == CompareLastYear {
+		return 12
+	}
+	return 1
+}
+
+// addMonths adds months to a date, clamping the day to the end of the
+// resulting month instead of overflowing into the next one.
+// Parameters:
+//   - t time.Time: Date to shift
+//   - months int: Number of months to add, negative to go back
+//
+// Returns:
+//   - time.Time: Shifted date
+func addMonths(t time.Time, months int) time.Time {
+	first := monthStart(t).AddDate(0, months, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// addComparisons adds comparison metrics to monthly occupancy and rate
+// analytics when the window requests a comparison. Months and rate windows
+// whose comparison period has no data are left without comparison.
+// Parameters:
+//   - data []models.RoomData: Booking data covering the window's fetch range
+//   - window analysisWindow: Resolved analysis window
+//   - occupancy []models.MonthlyOccupancy: Monthly occupancy to extend in place
+//   - rates *models.RateAnalytics: Rate analytics to extend
+func addComparisons(data []models.RoomData, window analysisWindow, occupancy []models.MonthlyOccupancy, rates *models.RateAnalytics) {
+	if window.compare == "" {
+		return
+	}
+	comparison := window.comparisonWindow()
+
+	previous := make(map[string]models.MonthlyOccupancy)
+	for _, monthly := range calculateMonthlyOccupancy(data, comparison.occupancyStart, comparison.occupancyEnd) {
+		previous[monthly.Month] = monthly
+	}
+	for i := range occupancy {
+		month, err := time.Parse("2006-01", occupancy[i].Month)
+		if err != nil {
+			continue
+		}
+		before, ok := previous[month.AddDate(0, -window.comparisonMonths(), 0).Format("2006-01")]
+		if !ok {
+			continue
+		}
+		occupancy[i].Comparison = &models.MonthlyComparison{
+			Month:               before.Month,
+			OccupancyPercentage: metricChange(occupancy[i].OccupancyPercentage, before.OccupancyPercentage),
+			Revenue:             metricChange(occupancy[i].Revenue, before.Revenue),
+			ADR:                 metricChange(occupancy[i].ADR, before.ADR),
+			RevPAR:              metricChange(occupancy[i].RevPAR, before.RevPAR),
+		}
+	}
+
+	before := calculateRateAnalytics(data, comparison.rateStart, comparison.rateEnd, nil)
+	if before.TotalNights == 0 {
+		return
+	}
+	rates.Comparison = &models.RateComparison{
+		From:         comparison.rateStart.Format(dateLayout),
+		To:           comparison.rateEnd.Format(dateLayout),
+		AverageRate:  metricChange(rates.AverageRate, before.AverageRate),
+		MedianRate:   metricChange(rates.MedianRate, before.MedianRate),
+		ADR:          metricChange(rates.ADR, before.ADR),
+		RevPAR:       metricChange(rates.RevPAR, before.RevPAR),
+		TotalRevenue: metricChange(rates.TotalRevenue, before.TotalRevenue),
+	}
+}
+
+// metricChange compares a current value with its comparison value.
+// Parameters:
+//   - current float64: Value in the analyzed period
+//   - comparison float64: Value in the comparison period
+//
+// Returns:
+//   - models.MetricChange: Comparison value with absolute and percent change
+func metricChange(current, comparison float64) models.MetricChange {
+	change := models.MetricChange{
+		Comparison: comparison,
+		Change:     round(current - comparison),
+	}
+	if comparison != 0 {
+		percent := round((current - comparison) / comparison * 100)
+		change.PercentChange = &percent
+	}
+	return change
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxExchangeRateAge is how many days an exchange rate stays valid when no
+// newer rate exists, covering weekends and holidays without published rates.
+// Days after today use the rate of today, as future rates aren't known.
+const maxExchangeRateAge = 7
+
+// ErrMissingExchangeRate is returned when a rate can't be converted for lack of an exchange rate
+var ErrMissingExchangeRate = errors.New("missing exchange rate")
+
+// resolveCurrency validates a requested target currency.
+// Parameters:
+//   - code string: Requested ISO 4217 code, empty to keep rates unconverted
+//
+// Returns:
+//   - string: Upper-case currency code, empty if none was requested
+//   - error: ErrInvalidOptions wrapped with details if the code is invalid
+func resolveCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code != "" && !models.ValidCurrency(code) {
+		return "", fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", ErrInvalidOptions)
+	}
+	return code, nil
+}
+
+// convertBookings converts the rates of bookings from each room's currency
+// into the target currency in place.
+// Parameters:
+//   - bookings []models.RoomBooking: Bookings to convert
+//   - target string: ISO 4217 code of the currency to convert to
+//   - start time.Time: First day of the bookings' date range
+//   - end time.Time: Last day of the bookings' date range
+//
+// Returns:
+//   - error: ErrMissingExchangeRate wrapped with details, or any storage error
+func (s *RoomService) convertBookings(bookings []models.RoomBooking, target string, start, end time.Time) error {
+	currencyOf, err := s.roomCurrencies()
+	if err != nil {
+		return err
+	}
+
+	var sources []string
+	for _, booking := range bookings {
+		sources = append(sources, currencyOf(booking.RoomID))
+	}
+
+	converter, err := s.newCurrencyConverter(target, sources, start, end)
+	if err != nil {
+		return err
+	}
+
+	for i := range bookings {
+		if err := converter.convert(&bookings[i].RoomData, currencyOf(bookings[i].RoomID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commonCurrency finds the currency shared by the rooms of bookings. Rates
+// of rooms priced in different currencies can't be summed or averaged
+// without converting them first.
+// Parameters:
+//   - bookings []models.RoomBooking: Bookings to be aggregated
+//
+// Returns:
+//   - string: ISO 4217 code of the rooms' currency, empty without bookings
+//   - error: ErrInvalidOptions wrapped with the currencies found if they differ, or any storage error
+func (s *RoomService) commonCurrency(bookings []models.RoomBooking) (string, error) {
+	currencyOf, err := s.roomCurrencies()
+	if err != nil {
+		return "", err
+	}
+
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, booking := range bookings {
+		if currency := currencyOf(booking.RoomID); !seen[currency] {
+			seen[currency] = true
+			currencies = append(currencies, currency)
+		}
+	}
+
+	switch len(currencies) {
+	case 0:
+		return "", nil
+	case 1:
+		return currencies[0], nil
+	}
+	sort.Strings(currencies)
+	return "", fmt.Errorf("%w: rooms are priced in %s, currency is required to convert them to one",
+		ErrInvalidOptions, strings.Join(currencies, ", "))
+}
+
+// roomCurrencies looks up the currency of every room.
+// Returns:
+//   - func(string) string: Currency of a room ID, models.DefaultCurrency for rooms without a record
+//   - error: Any storage error
+func (s *RoomService) roomCurrencies() (func(string) string, error) {
+	rooms, err := s.repo.ListRooms(models.RoomFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rooms: %v", err)
+	}
+
+	currencies := make(map[string]string, len(rooms))
+	for _, room := range rooms {
+		currencies[room.RoomID] = room.Currency
+	}
+	return func(roomID string) string {
+		if currency, ok := currencies[roomID]; ok {
+			return currency
+		}
+		return models.DefaultCurrency
+	}, nil
+}
+
+// currencyConverter converts daily rates between currencies using the
+// exchange rate of each day.
+type currencyConverter struct {
+	// target is the currency rates are converted to
+	target string
+	// today is the last day exchange rates can exist for, in YYYY-MM-DD format
+	today string
+	// rates maps currencies to their exchange rates in ascending date order
+	rates map[string][]models.ExchangeRate
+}
+
+// newCurrencyConverter loads the exchange rates needed to convert rates from
+// the source currencies into the target currency within a date range.
+// Parameters:
+//   - target string: ISO 4217 code of the currency to convert to
+//   - sources []string: ISO 4217 codes of the currencies to convert from
+//   - start time.Time: First day rates are converted for
+//   - end time.Time: Last day rates are converted for
+//
+// Returns:
+//   - *currencyConverter: Converter holding the loaded exchange rates
+//   - error: Any storage error
+func (s *RoomService) newCurrencyConverter(target string, sources []string, start, end time.Time) (*currencyConverter, error) {
+	today := s.today()
+	converter := &currencyConverter{
+		target: target,
+		today:  today.Format(dateLayout),
+		rates:  make(map[string][]models.ExchangeRate),
+	}
+
+	needed := make(map[string]bool)
+	for _, source := range sources {
+		if source != target {
+			needed[source] = true
+		}
+	}
+	if len(needed) == 0 {
+		return converter, nil
+	}
+	needed[target] = true
+	delete(needed, models.BaseCurrency)
+
+	currencies := make([]string, 0, len(needed))
+	for currency := range needed {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	// Days after today are converted at today's rate, which must be loaded too
+	if start.After(today) {
+		start = today
+	}
+	rates, err := s.repo.GetExchangeRates(currencies, start.AddDate(0, 0, -maxExchangeRateAge), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %v", err)
+	}
+	for _, rate := range rates {
+		converter.rates[rate.Currency] = append(converter.rates[rate.Currency], rate)
+	}
+
+	return converter, nil
+}
+
+// convert converts the rate of a day into the target currency in place.
+// Parameters:
+//   - day *models.RoomData: Day whose rate to convert
+//   - from string: ISO 4217 code of the currency the rate is quoted in
+//
+// Returns:
+//   - error: ErrMissingExchangeRate wrapped with details if either currency has no rate for the day
+func (c *currencyConverter) convert(day *models.RoomData, from string) error {
+	if from == c.target {
+		return nil
+	}
+
+	fromRate, err := c.rate(from, day.Date)
+	if err != nil {
+		return err
+	}
+	toRate, err := c.rate(c.target, day.Date)
+	if err != nil {
+		return err
+	}
+
+	// Round half up to cents rather than truncating, which would bias every converted night down
+	day.Rate = math.Round(day.Rate/fromRate*toRate*100) / 100
+	return nil
+}
+
+// rate finds the exchange rate of a currency on a day, falling back to the
+// most recent earlier rate of at most maxExchangeRateAge days. Days after
+// today are looked up as of today.
+// Parameters:
+//   - currency string: ISO 4217 code of the currency
+//   - date string: Day in YYYY-MM-DD format
+//
+// Returns:
+//   - float64: Units of the currency per one models.BaseCurrency
+//   - error: ErrMissingExchangeRate wrapped with details if there is no usable rate
+func (c *currencyConverter) rate(currency, date string) (float64, error) {
+	if currency == models.BaseCurrency {
+		return 1, nil
+	}
+
+	if date > c.today {
+		date = c.today
+	}
+
+	rates := c.rates[currency]
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].Date > date
+	})
+	if i > 0 {
+		day, dayErr := time.Parse(dateLayout, date)
+		rateDay, rateErr := time.Parse(dateLayout, rates[i-1].Date)
+		if dayErr == nil && rateErr == nil && !rateDay.Before(day.AddDate(0, 0, -maxExchangeRateAge)) {
+			return rates[i-1].Rate, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: no %s rate within %d days before %s", ErrMissingExchangeRate, currency, maxExchangeRateAge, date)
+}
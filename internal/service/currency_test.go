@@ -0,0 +1,159 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newMixedCurrencyService creates a service over rooms US1 priced in dollars
+// and EU1 and EU2 priced in euros, each with a rate worth 100 dollars every
+// night of March 2025, and today fixed to 2025-03-01.
+func newMixedCurrencyService(t *testing.T) *RoomService {
+	t.Helper()
+
+	var bookings []models.RoomBooking
+	for _, room := range []struct {
+		id   string
+		rate float64
+	}{{"US1", 100}, {"EU1", 50}, {"EU2", 50}} {
+		for _, booking := range monthBookings(room.id, "2025-03", 10) {
+			booking.Rate = room.rate
+			bookings = append(bookings, booking)
+		}
+	}
+
+	repo := newMemoryRepository(t, nil)
+	for _, room := range []models.Room{
+		{RoomID: "US1", RoomAttributes: models.RoomAttributes{Currency: "USD", TimeZone: "UTC"}},
+		{RoomID: "EU1", RoomAttributes: models.RoomAttributes{Currency: "EUR", TimeZone: "UTC"}},
+		{RoomID: "EU2", RoomAttributes: models.RoomAttributes{Currency: "EUR", TimeZone: "UTC"}},
+	} {
+		if err := repo.CreateRoom(room); err != nil {
+			t.Fatalf("creating room: %v", err)
+		}
+	}
+	if err := repo.UpsertBookings(bookings, time.Now()); err != nil {
+		t.Fatalf("seeding bookings: %v", err)
+	}
+	if err := repo.UpsertExchangeRates([]models.ExchangeRate{{Currency: "EUR", Date: "2025-03-01", Rate: 0.5}}); err != nil {
+		t.Fatalf("seeding exchange rates: %v", err)
+	}
+	return newFixedService(t, repo, "2025-03-01")
+}
+
+func TestMixedCurrencyAggregation(t *testing.T) {
+	s := newMixedCurrencyService(t)
+	window := AnalyticsOptions{From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+	withCurrency := func(currency string) AnalyticsOptions {
+		opts := window
+		opts.Currency = currency
+		return opts
+	}
+
+	tests := []struct {
+		name         string
+		roomIDs      []string
+		opts         AnalyticsOptions
+		wantCurrency string
+		wantRate     float64
+		wantErr      error
+	}{
+		{
+			name:    "rooms in different currencies are rejected",
+			roomIDs: []string{"US1", "EU1"},
+			opts:    window,
+			wantErr: ErrInvalidOptions,
+		},
+		{
+			name:         "rooms in different currencies are converted to the requested one",
+			roomIDs:      []string{"US1", "EU1"},
+			opts:         withCurrency("USD"),
+			wantCurrency: "USD",
+			wantRate:     100,
+		},
+		{
+			name:         "rooms sharing a currency are reported in it",
+			roomIDs:      []string{"EU1", "EU2"},
+			opts:         window,
+			wantCurrency: "EUR",
+			wantRate:     50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("portfolio/"+tt.name, func(t *testing.T) {
+			portfolio, err := s.GetPortfolioAnalytics(PortfolioOptions{AnalyticsOptions: tt.opts, RoomIDs: tt.roomIDs})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetPortfolioAnalytics() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPortfolioAnalytics() error = %v", err)
+			}
+			if portfolio.Currency != tt.wantCurrency || portfolio.RateAnalytics.AverageRate != tt.wantRate {
+				t.Errorf("currency, average rate = %q, %v, want %q, %v",
+					portfolio.Currency, portfolio.RateAnalytics.AverageRate, tt.wantCurrency, tt.wantRate)
+			}
+		})
+
+		t.Run("compare/"+tt.name, func(t *testing.T) {
+			comparison, err := s.CompareRooms(CompareOptions{AnalyticsOptions: tt.opts, RoomIDs: tt.roomIDs})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CompareRooms() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompareRooms() error = %v", err)
+			}
+			if comparison.Currency != tt.wantCurrency || comparison.GroupAverage.AverageRate != tt.wantRate {
+				t.Errorf("currency, group average rate = %q, %v, want %q, %v",
+					comparison.Currency, comparison.GroupAverage.AverageRate, tt.wantCurrency, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestCurrencyConverterConvert(t *testing.T) {
+	converter := &currencyConverter{
+		target: "USD",
+		today:  "2025-03-01",
+		rates: map[string][]models.ExchangeRate{
+			"EUR": {{Currency: "EUR", Date: "2025-03-01", Rate: 0.7}},
+			"GBP": {{Currency: "GBP", Date: "2025-02-20", Rate: 0.8}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		from    string
+		date    string
+		rate    float64
+		want    float64
+		wantErr error
+	}{
+		{name: "rounds to the nearest cent", from: "EUR", date: "2025-03-01", rate: 10, want: 14.29},
+		{name: "keeps exact conversions", from: "EUR", date: "2025-03-01", rate: 70, want: 100},
+		{name: "uses today's rate for future nights", from: "EUR", date: "2025-12-24", rate: 7, want: 10},
+		{name: "target currency is unchanged", from: "USD", date: "2025-03-01", rate: 10.555, want: 10.555},
+		{name: "rates older than a week are not used", from: "GBP", date: "2025-03-01", rate: 10, wantErr: ErrMissingExchangeRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := models.RoomData{Date: tt.date, Rate: tt.rate}
+			err := converter.convert(&day, tt.from)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("convert() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && day.Rate != tt.want {
+				t.Errorf("converted rate = %v, want %v", day.Rate, tt.want)
+			}
+		})
+	}
+}
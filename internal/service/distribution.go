@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// maxPercentiles limits the number of percentiles that can be requested
+const maxPercentiles = 10
+
+// DefaultPercentiles are reported when no percentile set is requested
+var DefaultPercentiles = []float64{10, 25, 75, 90}
+
+// ErrInvalidOptions is returned when analytics options other than the window are invalid
+var ErrInvalidOptions = errors.New("invalid analytics options")
+
+// resolvePercentiles validates a requested percentile set.
+// Parameters:
+//   - percentiles []float64: Requested percentiles, nil selects DefaultPercentiles
+//
+// Returns:
+//   - []float64: Sorted, de-duplicated percentiles
+//   - error: ErrInvalidOptions wrapped with details when validation fails
+func resolvePercentiles(percentiles []float64) ([]float64, error) {
+	if len(percentiles) == 0 {
+		return DefaultPercentiles, nil
+	}
+	if len(percentiles) > maxPercentiles {
+		return nil, fmt.Errorf("%w: at most %d percentiles can be requested", ErrInvalidOptions, maxPercentiles)
+	}
+
+	seen := make(map[float64]bool)
+	var resolved []float64
+	for _, p := range percentiles {
+		if math.IsNaN(p) || p < 0 || p > 100 {
+			return nil, fmt.Errorf("%w: percentiles must be between 0 and 100", ErrInvalidOptions)
+		}
+		if !seen[p] {
+			seen[p] = true
+			resolved = append(resolved, p)
+		}
+	}
+	sort.Float64s(resolved)
+
+	return resolved, nil
+}
+
+// percentile calculates a percentile of sorted values using linear
+// interpolation between the closest ranks.
+// Parameters:
+//   - sorted []float64: Values in ascending order, must not be empty
+//   - p float64: Percentile between 0 and 100
+//
+// Returns:
+//   - float64: Interpolated percentile value
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*weight
+}
+
+// standardDeviation calculates the population standard deviation of values.
+// Parameters:
+//   - values []float64: Values, must not be empty
+//   - mean float64: Mean of the values
+//
+// Returns:
+//   - float64: Population standard deviation
+func standardDeviation(values []float64, mean float64) float64 {
+	var sumSquares float64
+	for _, v := range values {
+		sumSquares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sumSquares / float64(len(values)))
+}
+
+// percentileKey formats a percentile as a response key, e.g. 90 as "p90".
+// Parameters:
+//   - p float64: Percentile between 0 and 100
+//
+// Returns:
+//   - string: Key for the percentile
+func percentileKey(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
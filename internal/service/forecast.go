@@ -0,0 +1,455 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultForecastMonths is the forecast horizon when none is requested
+	DefaultForecastMonths = 3
+	// MaxForecastMonths limits the forecast horizon
+	MaxForecastMonths = 12
+	// DefaultSmoothingAlpha is the exponential smoothing factor when none is requested
+	DefaultSmoothingAlpha = 0.5
+	// forecastHistoryMonths is the number of complete months used as history
+	forecastHistoryMonths = 36
+	// minForecastHistory is the minimum number of observed months needed to forecast
+	minForecastHistory = 2
+	// seasonLength is the number of months after which occupancy patterns repeat
+	seasonLength = 12
+	// forecastConfidence is the confidence level of the reported intervals
+	forecastConfidence = 95
+	// forecastZ is the normal quantile matching forecastConfidence
+	forecastZ = 1.96
+)
+
+const (
+	// ForecastSeasonalNaive repeats the occupancy of the same month one year earlier
+	ForecastSeasonalNaive = "seasonal_naive"
+	// ForecastExponentialSmoothing projects the exponentially smoothed occupancy level
+	ForecastExponentialSmoothing = "exponential_smoothing"
+)
+
+// ErrInsufficientHistory is returned when a room has too little history to forecast
+var ErrInsufficientHistory = errors.New("insufficient booking history")
+
+// ForecastOptions describes a requested occupancy forecast.
+// Zero values select the defaults.
+type ForecastOptions struct {
+	// AsOf is the date the forecast is made on; history ends with the month before
+	AsOf time.Time
+	// Months is the forecast horizon starting with the AsOf month
+	Months int
+	// Method selects the forecasting method; empty selects seasonal naive when
+	// more than a year of history exists and exponential smoothing otherwise
+	Method string
+	// Alpha is the exponential smoothing factor in (0, 1]
+	Alpha float64
+	// Backtest additionally forecasts the last Months months of history and
+	// reports the error against the known occupancy
+	Backtest bool
+}
+
+// seriesPoint is a single month of an occupancy history.
+type seriesPoint struct {
+	month    time.Time
+	value    float64
+	observed bool
+}
+
+// forecastValue is a point estimate with its confidence interval.
+type forecastValue struct {
+	estimate float64
+	lower    float64
+	upper    float64
+}
+
+// ForecastOccupancy projects the monthly occupancy of a room from its history.
+// History covers up to 36 complete months before the month of opts.AsOf
+// (today by default); the forecast starts with the AsOf month itself.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts ForecastOptions: Horizon, method and backtest settings
+//
+// Returns:
+//   - *models.OccupancyForecast: Forecast with 95% confidence intervals
+//   - error: ErrInvalidOptions, ErrRoomNotFound or ErrInsufficientHistory
+//     wrapped with details, or any error encountered while fetching data
+func (s *RoomService) ForecastOccupancy(roomID string, opts ForecastOptions) (*models.OccupancyForecast, error) {
+	asOf, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.AsOf.IsZero() {
+		asOf = truncateToDay(opts.AsOf)
+	}
+
+	months := opts.Months
+	if months == 0 {
+		months = DefaultForecastMonths
+	}
+	if months < 1 || months > MaxForecastMonths {
+		return nil, fmt.Errorf("%w: months must be between 1 and %d", ErrInvalidOptions, MaxForecastMonths)
+	}
+
+	alpha := opts.Alpha
+	if alpha == 0 {
+		alpha = DefaultSmoothingAlpha
+	}
+	if math.IsNaN(alpha) || alpha <= 0 || alpha > 1 {
+		return nil, fmt.Errorf("%w: alpha must be greater than 0 and at most 1", ErrInvalidOptions)
+	}
+
+	switch opts.Method {
+	case "", ForecastSeasonalNaive, ForecastExponentialSmoothing:
+	default:
+		return nil, fmt.Errorf("%w: method must be %s or %s", ErrInvalidOptions, ForecastSeasonalNaive, ForecastExponentialSmoothing)
+	}
+
+	forecastStart := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	historyStart := forecastStart.AddDate(0, -forecastHistoryMonths, 0)
+	historyEnd := forecastStart.AddDate(0, 0, -1)
+
+	roomData, err := s.repo.GetRoomData(roomID, historyStart, historyEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room data: %v", err)
+	}
+	if len(roomData) == 0 {
+		// Rooms without data before the forecast still exist if they have a record
+		if err := s.requireRoom(roomID); err != nil {
+			return nil, err
+		}
+	}
+
+	series := monthlySeries(calculateMonthlyOccupancy(roomData, historyStart, historyEnd), historyEnd)
+	if observedMonths(series) < minForecastHistory {
+		return nil, fmt.Errorf("%w: at least %d months of history are required", ErrInsufficientHistory, minForecastHistory)
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = ForecastExponentialSmoothing
+		if observedMonths(series) > seasonLength {
+			method = ForecastSeasonalNaive
+		}
+	}
+
+	forecast := &models.OccupancyForecast{
+		RoomID:          roomID,
+		AsOf:            asOf.Format(dateLayout),
+		Method:          method,
+		ConfidenceLevel: forecastConfidence,
+		HistoryFrom:     series[0].month.Format("2006-01"),
+		HistoryTo:       historyEnd.Format("2006-01"),
+		Forecast:        forecastPoints(forecastSeries(series, method, alpha, months), forecastStart),
+	}
+	if method == ForecastExponentialSmoothing {
+		forecast.Alpha = &alpha
+	}
+
+	if opts.Backtest {
+		backtest, err := backtestSeries(series, method, alpha, months)
+		if err != nil {
+			return nil, err
+		}
+		forecast.Backtest = backtest
+	}
+
+	return forecast, nil
+}
+
+// monthlySeries turns monthly occupancy into a contiguous monthly series
+// from the first observed month up to the month of end. Months without
+// data are kept as unobserved points.
+// Parameters:
+//   - occupancy []models.MonthlyOccupancy: Monthly occupancy sorted by month
+//   - end time.Time: Last day of the history
+//
+// Returns:
+//   - []seriesPoint: Contiguous monthly series, empty without occupancy data
+func monthlySeries(occupancy []models.MonthlyOccupancy, end time.Time) []seriesPoint {
+	if len(occupancy) == 0 {
+		return nil
+	}
+
+	values := make(map[string]float64)
+	for _, monthly := range occupancy {
+		values[monthly.Month] = monthly.OccupancyPercentage
+	}
+
+	first, err := time.Parse("2006-01", occupancy[0].Month)
+	if err != nil {
+		return nil
+	}
+
+	var series []seriesPoint
+	for month := first; !month.After(end); month = month.AddDate(0, 1, 0) {
+		value, ok := values[month.Format("2006-01")]
+		series = append(series, seriesPoint{month: month, value: value, observed: ok})
+	}
+	return series
+}
+
+// observedMonths counts the months of a series that have data.
+// Parameters:
+//   - series []seriesPoint: Monthly series
+//
+// Returns:
+//   - int: Number of observed months
+func observedMonths(series []seriesPoint) int {
+	count := 0
+	for _, point := range series {
+		if point.observed {
+			count++
+		}
+	}
+	return count
+}
+
+// forecastSeries forecasts the months following a series.
+// Parameters:
+//   - series []seriesPoint: Monthly history with at least one observed month
+//   - method string: ForecastSeasonalNaive or ForecastExponentialSmoothing
+//   - alpha float64: Smoothing factor for exponential smoothing
+//   - horizon int: Number of months to forecast
+//
+// Returns:
+//   - []forecastValue: Estimates for the next horizon months, clamped to 0-100
+func forecastSeries(series []seriesPoint, method string, alpha float64, horizon int) []forecastValue {
+	if method == ForecastSeasonalNaive {
+		return seasonalNaive(series, horizon)
+	}
+	return exponentialSmoothing(series, alpha, horizon)
+}
+
+// seasonalNaive forecasts each month with the value of the same month one
+// season earlier, falling back to the last observed value when that month
+// has no data. The interval widens with every season the forecast reaches ahead.
+// Parameters:
+//   - series []seriesPoint: Monthly history with at least one observed month
+//   - horizon int: Number of months to forecast
+//
+// Returns:
+//   - []forecastValue: Estimates for the next horizon months
+func seasonalNaive(series []seriesPoint, horizon int) []forecastValue {
+	extended := append([]seriesPoint(nil), series...)
+	last := lastObserved(series)
+
+	var residuals []float64
+	for i := seasonLength; i < len(series); i++ {
+		if series[i].observed && series[i-seasonLength].observed {
+			residuals = append(residuals, series[i].value-series[i-seasonLength].value)
+		}
+	}
+	if len(residuals) == 0 {
+		// Without a full season of pairs use month-over-month changes
+		residuals = naiveResiduals(series)
+	}
+	sigma := rootMeanSquare(residuals)
+
+	values := make([]forecastValue, 0, horizon)
+	for h := 1; h <= horizon; h++ {
+		estimate := last
+		if source := len(extended) - seasonLength; source >= 0 && extended[source].observed {
+			estimate = extended[source].value
+		}
+		extended = append(extended, seriesPoint{value: estimate, observed: true})
+
+		seasons := float64((h-1)/seasonLength + 1)
+		values = append(values, newForecastValue(estimate, forecastZ*sigma*math.Sqrt(seasons)))
+	}
+	return values
+}
+
+// exponentialSmoothing forecasts a flat level computed by simple exponential
+// smoothing over the observed months.
+// Parameters:
+//   - series []seriesPoint: Monthly history with at least one observed month
+//   - alpha float64: Smoothing factor in (0, 1]
+//   - horizon int: Number of months to forecast
+//
+// Returns:
+//   - []forecastValue: Estimates for the next horizon months
+func exponentialSmoothing(series []seriesPoint, alpha float64, horizon int) []forecastValue {
+	var level float64
+	var residuals []float64
+	initialized := false
+	for _, point := range series {
+		if !point.observed {
+			continue
+		}
+		if !initialized {
+			level = point.value
+			initialized = true
+			continue
+		}
+		residual := point.value - level
+		residuals = append(residuals, residual)
+		level += alpha * residual
+	}
+	sigma := rootMeanSquare(residuals)
+
+	values := make([]forecastValue, 0, horizon)
+	for h := 1; h <= horizon; h++ {
+		spread := forecastZ * sigma * math.Sqrt(1+float64(h-1)*alpha*alpha)
+		values = append(values, newForecastValue(level, spread))
+	}
+	return values
+}
+
+// newForecastValue builds a forecast value with a symmetric interval,
+// clamped to valid occupancy percentages.
+// Parameters:
+//   - estimate float64: Point estimate
+//   - spread float64: Half width of the confidence interval
+//
+// Returns:
+//   - forecastValue: Clamped estimate and interval
+func newForecastValue(estimate, spread float64) forecastValue {
+	return forecastValue{
+		estimate: clampPercentage(estimate),
+		lower:    clampPercentage(estimate - spread),
+		upper:    clampPercentage(estimate + spread),
+	}
+}
+
+// backtestSeries forecasts the last horizon months of a series from the
+// months before them and measures the error against the observed values.
+// Parameters:
+//   - series []seriesPoint: Monthly history
+//   - method string: Forecasting method to evaluate
+//   - alpha float64: Smoothing factor for exponential smoothing
+//   - horizon int: Number of held out months
+//
+// Returns:
+//   - *models.ForecastBacktest: Held out forecasts with error metrics
+//   - error: ErrInsufficientHistory wrapped with details if too little history remains
+func backtestSeries(series []seriesPoint, method string, alpha float64, horizon int) (*models.ForecastBacktest, error) {
+	if len(series) <= horizon || observedMonths(series[:len(series)-horizon]) < minForecastHistory {
+		return nil, fmt.Errorf("%w: backtesting %d months requires at least %d earlier months of history",
+			ErrInsufficientHistory, horizon, minForecastHistory)
+	}
+
+	training := series[:len(series)-horizon]
+	holdout := series[len(series)-horizon:]
+	points := forecastPoints(forecastSeries(training, method, alpha, horizon), holdout[0].month)
+
+	backtest := &models.ForecastBacktest{
+		From:   holdout[0].month.Format("2006-01"),
+		To:     holdout[len(holdout)-1].month.Format("2006-01"),
+		Points: points,
+	}
+
+	var absolute, squared, percentage float64
+	var percentageCount int
+	for i, point := range holdout {
+		if !point.observed {
+			continue
+		}
+		actual := point.value
+		points[i].Actual = &actual
+
+		diff := points[i].OccupancyPercentage - actual
+		absolute += math.Abs(diff)
+		squared += diff * diff
+		if actual != 0 {
+			percentage += math.Abs(diff / actual)
+			percentageCount++
+		}
+		backtest.EvaluatedMonths++
+	}
+
+	if backtest.EvaluatedMonths > 0 {
+		n := float64(backtest.EvaluatedMonths)
+		backtest.MAE = round(absolute / n)
+		backtest.RMSE = round(math.Sqrt(squared / n))
+	}
+	if percentageCount > 0 {
+		mape := round(percentage / float64(percentageCount) * 100)
+		backtest.MAPE = &mape
+	}
+
+	return backtest, nil
+}
+
+// forecastPoints converts forecast values into consecutive monthly points.
+// Parameters:
+//   - values []forecastValue: Forecast values in month order
+//   - start time.Time: First day of the first forecast month
+//
+// Returns:
+//   - []models.ForecastPoint: Monthly forecast points
+func forecastPoints(values []forecastValue, start time.Time) []models.ForecastPoint {
+	points := make([]models.ForecastPoint, 0, len(values))
+	for i, value := range values {
+		points = append(points, models.ForecastPoint{
+			Month:               start.AddDate(0, i, 0).Format("2006-01"),
+			OccupancyPercentage: round(value.estimate),
+			LowerBound:          round(value.lower),
+			UpperBound:          round(value.upper),
+		})
+	}
+	return points
+}
+
+// lastObserved returns the most recent observed value of a series.
+// Parameters:
+//   - series []seriesPoint: Monthly series
+//
+// Returns:
+//   - float64: Last observed value, zero if there is none
+func lastObserved(series []seriesPoint) float64 {
+	for i := len(series) - 1; i >= 0; i-- {
+		if series[i].observed {
+			return series[i].value
+		}
+	}
+	return 0
+}
+
+// naiveResiduals returns the changes between consecutive observed months.
+// Parameters:
+//   - series []seriesPoint: Monthly series
+//
+// Returns:
+//   - []float64: Month-over-month differences
+func naiveResiduals(series []seriesPoint) []float64 {
+	var residuals []float64
+	for i := 1; i < len(series); i++ {
+		if series[i].observed && series[i-1].observed {
+			residuals = append(residuals, series[i].value-series[i-1].value)
+		}
+	}
+	return residuals
+}
+
+// rootMeanSquare calculates the root mean square of forecast residuals.
+// Parameters:
+//   - residuals []float64: Forecast errors
+//
+// Returns:
+//   - float64: Root mean square, zero without residuals
+func rootMeanSquare(residuals []float64) float64 {
+	if len(residuals) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, residual := range residuals {
+		sum += residual * residual
+	}
+	return math.Sqrt(sum / float64(len(residuals)))
+}
+
+// clampPercentage limits a value to the 0-100 range.
+// Parameters:
+//   - value float64: Value to clamp
+//
+// Returns:
+//   - float64: Clamped value
+func clampPercentage(value float64) float64 {
+	return math.Max(0, math.Min(100, value))
+}
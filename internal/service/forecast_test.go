@@ -0,0 +1,238 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// forecastBookings builds the calendar of room R1 from its booked days per month.
+func forecastBookings(months map[string]int) []models.RoomBooking {
+	var bookings []models.RoomBooking
+	for month, booked := range months {
+		bookings = append(bookings, monthBookings("R1", month, booked)...)
+	}
+	return bookings
+}
+
+// yearOf adds the booked days of every month of a year to months.
+func yearOf(year int, booked func(month time.Month) int, months map[string]int) map[string]int {
+	for month := time.January; month <= time.December; month++ {
+		months[time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Format("2006-01")] = booked(month)
+	}
+	return months
+}
+
+// fullOddMonths books every day of odd months and none of even months.
+func fullOddMonths(month time.Month) int {
+	return int(month%2) * 31
+}
+
+// allDays returns a function booking days whole months or none of them.
+func allDays(booked bool) func(time.Month) int {
+	return func(time.Month) int {
+		if booked {
+			return 31
+		}
+		return 0
+	}
+}
+
+func TestForecastOccupancy(t *testing.T) {
+	point := func(month string, estimate, lower, upper float64) models.ForecastPoint {
+		return models.ForecastPoint{Month: month, OccupancyPercentage: estimate, LowerBound: lower, UpperBound: upper}
+	}
+	actual := func(p models.ForecastPoint, value float64) models.ForecastPoint {
+		p.Actual = &value
+		return p
+	}
+	mape := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name         string
+		asOf         string
+		months       map[string]int
+		opts         ForecastOptions
+		wantMethod   string
+		wantForecast []models.ForecastPoint
+		wantBacktest *models.ForecastBacktest
+	}{
+		{
+			name:       "seasonal naive repeats last year with a zero interval for identical years",
+			asOf:       "2025-01-15",
+			months:     yearOf(2023, fullOddMonths, yearOf(2024, fullOddMonths, map[string]int{})),
+			opts:       ForecastOptions{Months: 2, Backtest: true},
+			wantMethod: ForecastSeasonalNaive,
+			wantForecast: []models.ForecastPoint{
+				point("2025-01", 100, 100, 100),
+				point("2025-02", 0, 0, 0),
+			},
+			wantBacktest: &models.ForecastBacktest{
+				From: "2024-11",
+				To:   "2024-12",
+				Points: []models.ForecastPoint{
+					actual(point("2024-11", 100, 100, 100), 100),
+					actual(point("2024-12", 0, 0, 0), 0),
+				},
+				EvaluatedMonths: 2,
+				MAPE:            mape(0),
+			},
+		},
+		{
+			name:       "seasonal naive backtest measures the change between years",
+			asOf:       "2025-01-15",
+			months:     yearOf(2023, allDays(true), yearOf(2024, allDays(false), map[string]int{})),
+			opts:       ForecastOptions{Months: 1, Backtest: true},
+			wantMethod: ForecastSeasonalNaive,
+			wantForecast: []models.ForecastPoint{
+				point("2025-01", 0, 0, 100),
+			},
+			wantBacktest: &models.ForecastBacktest{
+				From: "2024-12",
+				To:   "2024-12",
+				Points: []models.ForecastPoint{
+					actual(point("2024-12", 100, 0, 100), 0),
+				},
+				EvaluatedMonths: 1,
+				MAE:             100,
+				RMSE:            100,
+			},
+		},
+		{
+			name:       "exponential smoothing is the default for short histories",
+			asOf:       "2024-12-10",
+			months:     map[string]int{"2024-09": 30, "2024-10": 0, "2024-11": 30},
+			opts:       ForecastOptions{Months: 2},
+			wantMethod: ForecastExponentialSmoothing,
+			wantForecast: []models.ForecastPoint{
+				point("2024-12", 75, 0, 100),
+				point("2025-01", 75, 0, 100),
+			},
+		},
+		{
+			name:       "exponential smoothing forecasts from the month of as of",
+			asOf:       "2025-01-15",
+			months:     map[string]int{"2024-10": 31, "2024-11": 0, "2024-12": 31},
+			opts:       ForecastOptions{Months: 1},
+			wantMethod: ForecastExponentialSmoothing,
+			wantForecast: []models.ForecastPoint{
+				point("2025-01", 75, 0, 100),
+			},
+		},
+		{
+			name:       "exponential smoothing with alpha 1 repeats the last month",
+			asOf:       "2024-12-10",
+			months:     map[string]int{"2024-09": 30, "2024-10": 31, "2024-11": 15},
+			opts:       ForecastOptions{Months: 1, Alpha: 1},
+			wantMethod: ForecastExponentialSmoothing,
+			wantForecast: []models.ForecastPoint{
+				point("2024-12", 50, 0, 100),
+			},
+		},
+		{
+			name:       "exponential smoothing backtest holds out the last month",
+			asOf:       "2024-12-10",
+			months:     map[string]int{"2024-09": 30, "2024-10": 0, "2024-11": 30},
+			opts:       ForecastOptions{Months: 1, Backtest: true, Method: ForecastExponentialSmoothing},
+			wantMethod: ForecastExponentialSmoothing,
+			wantForecast: []models.ForecastPoint{
+				point("2024-12", 75, 0, 100),
+			},
+			wantBacktest: &models.ForecastBacktest{
+				From: "2024-11",
+				To:   "2024-11",
+				Points: []models.ForecastPoint{
+					actual(point("2024-11", 50, 0, 100), 100),
+				},
+				EvaluatedMonths: 1,
+				MAE:             50,
+				RMSE:            50,
+				MAPE:            mape(50),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFixedService(t, newMemoryRepository(t, forecastBookings(tt.months)), tt.asOf)
+
+			forecast, err := s.ForecastOccupancy("R1", tt.opts)
+			if err != nil {
+				t.Fatalf("ForecastOccupancy() error = %v", err)
+			}
+			if forecast.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", forecast.Method, tt.wantMethod)
+			}
+			if !reflect.DeepEqual(forecast.Forecast, tt.wantForecast) {
+				t.Errorf("Forecast = %+v, want %+v", forecast.Forecast, tt.wantForecast)
+			}
+			if !reflect.DeepEqual(forecast.Backtest, tt.wantBacktest) {
+				t.Errorf("Backtest = %+v, want %+v", forecast.Backtest, tt.wantBacktest)
+			}
+		})
+	}
+}
+
+func TestForecastOccupancyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		roomID string
+		months map[string]int
+		opts   ForecastOptions
+		want   error
+	}{
+		{
+			name:   "unknown room",
+			roomID: "MISSING",
+			want:   ErrRoomNotFound,
+		},
+		{
+			name:   "room without history",
+			roomID: "EMPTY",
+			want:   ErrInsufficientHistory,
+		},
+		{
+			name:   "single month of history",
+			roomID: "R1",
+			months: map[string]int{"2024-11": 10},
+			want:   ErrInsufficientHistory,
+		},
+		{
+			name:   "backtest longer than the history allows",
+			roomID: "R1",
+			months: map[string]int{"2024-10": 10, "2024-11": 10},
+			opts:   ForecastOptions{Months: 1, Backtest: true},
+			want:   ErrInsufficientHistory,
+		},
+		{
+			name:   "unknown method",
+			roomID: "R1",
+			months: map[string]int{"2024-10": 10, "2024-11": 10},
+			opts:   ForecastOptions{Method: "arima"},
+			want:   ErrInvalidOptions,
+		},
+		{
+			name:   "alpha above 1",
+			roomID: "R1",
+			months: map[string]int{"2024-10": 10, "2024-11": 10},
+			opts:   ForecastOptions{Alpha: 1.5},
+			want:   ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository(t, forecastBookings(tt.months))
+			if err := repo.CreateRoom(models.Room{RoomID: "EMPTY"}); err != nil {
+				t.Fatalf("creating room: %v", err)
+			}
+			s := newFixedService(t, repo, "2024-12-10")
+
+			if _, err := s.ForecastOccupancy(tt.roomID, tt.opts); !errors.Is(err, tt.want) {
+				t.Errorf("ForecastOccupancy() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
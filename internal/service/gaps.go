@@ -0,0 +1,176 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultMaxGap is the longest unbooked run counted as an orphan gap by default
+	DefaultMaxGap = 2
+	// maxGapLimit limits the requested orphan gap length
+	maxGapLimit = 14
+	// defaultGapDays is the number of days analyzed when no end date is requested
+	defaultGapDays = 90
+	// maxGapDays limits the number of days analyzed per request
+	maxGapDays = 366
+)
+
+// GapOptions describes a requested calendar gap analysis.
+// Zero values select the defaults.
+type GapOptions struct {
+	// From is the first day to analyze, defaults to today
+	From time.Time
+	// To is the last day to analyze, defaults to 90 days starting at From
+	To time.Time
+	// MaxGap is the longest unbooked run between two bookings counted as an orphan gap
+	MaxGap int
+}
+
+// GetCalendarGaps finds orphan gaps, booked and available streaks and the
+// longest available window in a room's calendar.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts GapOptions: Range and orphan gap length
+//
+// Returns:
+//   - *models.GapAnalysis: Streaks and orphan gaps within the range
+//   - error: ErrInvalidWindow, ErrInvalidOptions or ErrRoomNotFound, or any
+//     error encountered while fetching data
+func (s *RoomService) GetCalendarGaps(roomID string, opts GapOptions) (*models.GapAnalysis, error) {
+	from, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+	to := from.AddDate(0, 0, defaultGapDays-1)
+	if !opts.To.IsZero() {
+		to = truncateToDay(opts.To)
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", ErrInvalidWindow)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxGapDays {
+		return nil, fmt.Errorf("%w: at most %d days can be analyzed at once", ErrInvalidWindow, maxGapDays)
+	}
+
+	maxGap, err := resolveMaxGap(opts.MaxGap)
+	if err != nil {
+		return nil, err
+	}
+
+	roomData, err := s.repo.GetRoomData(roomID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room data: %v", err)
+	}
+	if len(roomData) == 0 {
+		// Rooms with a record but no data in the range have an empty calendar
+		if err := s.requireRoom(roomID); err != nil {
+			return nil, err
+		}
+	}
+
+	analysis := analyzeGaps(roomData, from, to, maxGap)
+	analysis.RoomID = roomID
+	return &analysis, nil
+}
+
+// resolveMaxGap validates a requested orphan gap length.
+// Parameters:
+//   - maxGap int: Requested length, zero selects DefaultMaxGap
+//
+// Returns:
+//   - int: Orphan gap length to use
+//   - error: ErrInvalidOptions wrapped with details when out of range
+func resolveMaxGap(maxGap int) (int, error) {
+	if maxGap == 0 {
+		return DefaultMaxGap, nil
+	}
+	if maxGap < 1 || maxGap > maxGapLimit {
+		return 0, fmt.Errorf("%w: max_gap must be between 1 and %d", ErrInvalidOptions, maxGapLimit)
+	}
+	return maxGap, nil
+}
+
+// analyzeGaps splits a calendar into streaks of booked and available days and
+// finds the orphan gaps among them. An orphan gap is an available streak of at
+// most maxGap nights with booked days directly before and after it. Days
+// missing from the calendar end a streak, and streaks touching the range
+// boundaries or missing days are never orphans since their neighbours are unknown.
+// Parameters:
+//   - data []models.RoomData: Room booking data ordered by date
+//   - start time.Time: First day of the range
+//   - end time.Time: Last day of the range (inclusive)
+//   - maxGap int: Longest available streak counted as an orphan gap
+//
+// Returns:
+//   - models.GapAnalysis: Streaks and orphan gaps without a room ID
+func analyzeGaps(data []models.RoomData, start, end time.Time, maxGap int) models.GapAnalysis {
+	analysis := models.GapAnalysis{
+		From:       start.Format(dateLayout),
+		To:         end.Format(dateLayout),
+		MaxGap:     maxGap,
+		Streaks:    []models.Streak{},
+		OrphanGaps: []models.Streak{},
+	}
+
+	// joined records whether each streak directly follows the previous one;
+	// joined neighbours always differ in status
+	var joined []bool
+	var previous time.Time
+	for _, day := range data {
+		date, err := time.Parse(dateLayout, day.Date)
+		if err != nil || !inRange(date, start, end) {
+			continue
+		}
+
+		status := models.DayStatusAvailable
+		if day.IsBooked {
+			status = models.DayStatusBooked
+		}
+
+		contiguous := !previous.IsZero() && date.Equal(previous.AddDate(0, 0, 1))
+		last := len(analysis.Streaks) - 1
+		if contiguous && analysis.Streaks[last].Status == status {
+			analysis.Streaks[last].End = day.Date
+			analysis.Streaks[last].Nights++
+		} else {
+			analysis.Streaks = append(analysis.Streaks, models.Streak{
+				Status: status,
+				Start:  day.Date,
+				End:    day.Date,
+				Nights: 1,
+			})
+			joined = append(joined, contiguous)
+		}
+
+		previous = date
+	}
+
+	for i, streak := range analysis.Streaks {
+		if streak.Status == models.DayStatusBooked {
+			if analysis.LongestBooked == nil || streak.Nights > analysis.LongestBooked.Nights {
+				analysis.LongestBooked = &analysis.Streaks[i]
+			}
+			continue
+		}
+
+		if analysis.LongestAvailable == nil || streak.Nights > analysis.LongestAvailable.Nights {
+			analysis.LongestAvailable = &analysis.Streaks[i]
+		}
+
+		// Joined neighbours of an available streak are booked streaks
+		closedAfter := i+1 < len(analysis.Streaks) && joined[i+1]
+		if joined[i] && closedAfter && streak.Nights <= maxGap {
+			analysis.OrphanGaps = append(analysis.OrphanGaps, streak)
+			analysis.OrphanNights += streak.Nights
+		}
+	}
+	analysis.OrphanGapCount = len(analysis.OrphanGaps)
+
+	return analysis
+}
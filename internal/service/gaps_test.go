@@ -0,0 +1,31 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetCalendarGapsWithoutData(t *testing.T) {
+	repo := newMemoryRepository(t, monthBookings("R1", "2025-01", 10))
+	if err := repo.CreateRoom(models.Room{RoomID: "EMPTY"}); err != nil {
+		t.Fatalf("creating room: %v", err)
+	}
+	s := newFixedService(t, repo, "2025-03-01")
+	opts := GapOptions{From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+
+	if _, err := s.GetCalendarGaps("MISSING", opts); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("GetCalendarGaps() of an unknown room error = %v, want %v", err, ErrRoomNotFound)
+	}
+
+	for _, roomID := range []string{"EMPTY", "R1"} {
+		analysis, err := s.GetCalendarGaps(roomID, opts)
+		if err != nil {
+			t.Fatalf("GetCalendarGaps(%q) error = %v", roomID, err)
+		}
+		if analysis.RoomID != roomID || len(analysis.Streaks) != 0 || len(analysis.OrphanGaps) != 0 {
+			t.Errorf("GetCalendarGaps(%q) = %+v, want an empty analysis", roomID, analysis)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repository"
+	"testing"
+	"time"
+)
+
+// newMemoryRepository creates a memory repository holding bookings.
+func newMemoryRepository(t *testing.T, bookings []models.RoomBooking) *repository.MemoryRoomRepository {
+	t.Helper()
+
+	repo := repository.NewMemoryRoomRepository()
+	if err := repo.UpsertBookings(bookings, time.Now()); err != nil {
+		t.Fatalf("seeding bookings: %v", err)
+	}
+	return repo
+}
+
+// newFixedService creates a service over repo whose today is asOf.
+func newFixedService(t *testing.T, repo repository.RoomRepository, asOf string) *RoomService {
+	t.Helper()
+
+	now, err := time.Parse(dateLayout, asOf)
+	if err != nil {
+		t.Fatalf("parsing as of date: %v", err)
+	}
+	return NewRoomService(repo, WithClock(FixedClock(now)))
+}
+
+// monthBookings builds the calendar of a month with its first bookedDays days booked.
+func monthBookings(roomID, month string, bookedDays int) []models.RoomBooking {
+	first, err := time.Parse("2006-01", month)
+	if err != nil {
+		panic(err)
+	}
+
+	var bookings []models.RoomBooking
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		bookings = append(bookings, models.RoomBooking{
+			RoomID: roomID,
+			RoomData: models.RoomData{
+				Date:     day.Format(dateLayout),
+				IsBooked: day.Day() <= bookedDays,
+				Rate:     100,
+			},
+		})
+	}
+	return bookings
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"airbnb-analytics/internal/importer"
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxFeedDays limits the number of days a single feed ingestion may update
+const maxFeedDays = 2 * 366
+
+// ICSOptions controls how an iCalendar feed is applied to a room's calendar.
+type ICSOptions struct {
+	// From is the first day to update, defaults to today
+	From time.Time
+	// To is the last day to update, defaults to the last booked night in the feed
+	To time.Time
+	// DefaultRate is the rate stored for days that don't exist yet
+	DefaultRate float64
+}
+
+// IngestICS applies an iCalendar availability feed to a room. Every day in
+// the window is marked booked if a VEVENT covers it and available otherwise.
+// Rates of existing days are left intact.
+// Parameters:
+//   - roomID string: Room the feed belongs to
+//   - reader io.Reader: Source of the iCalendar data
+//   - opts ICSOptions: Window and defaults for the ingestion
+//
+// Returns:
+//   - *models.AvailabilityImportReport: Summary of the updated days
+//   - error: ErrInvalidImport for unusable input, or any storage error
+func (s *RoomService) IngestICS(roomID string, reader io.Reader, opts ICSOptions) (*models.AvailabilityImportReport, error) {
+	if strings.TrimSpace(roomID) == "" {
+		return nil, fmt.Errorf("%w: room ID is required", ErrInvalidImport)
+	}
+	if len(roomID) > models.MaxRoomIDLength {
+		return nil, fmt.Errorf("%w: room ID must be at most %d characters", ErrInvalidImport, models.MaxRoomIDLength)
+	}
+	if opts.DefaultRate < 0 {
+		return nil, fmt.Errorf("%w: default rate must not be negative", ErrInvalidImport)
+	}
+
+	events, err := importer.ParseICS(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	from, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+
+	to := truncateToDay(opts.To)
+	if opts.To.IsZero() {
+		for _, event := range events {
+			if lastNight := event.End.AddDate(0, 0, -1); lastNight.After(to) {
+				to = lastNight
+			}
+		}
+		if to.Before(from) {
+			return nil, fmt.Errorf("%w: feed has no stays on or after %s, an end date is required", ErrInvalidImport, from.Format(dateLayout))
+		}
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: end date must not be before start date", ErrInvalidImport)
+	}
+	if to.After(from.AddDate(0, 0, maxFeedDays-1)) {
+		return nil, fmt.Errorf("%w: window must not exceed %d days", ErrInvalidImport, maxFeedDays)
+	}
+
+	booked := importer.BookedDates(events, from, to)
+	report := &models.AvailabilityImportReport{
+		RoomID: roomID,
+		From:   from.Format(dateLayout),
+		To:     to.Format(dateLayout),
+		Events: len(events),
+	}
+
+	var days []models.RoomBooking
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		if booked[date] {
+			report.BookedDays++
+		} else {
+			report.AvailableDays++
+		}
+		days = append(days, models.RoomBooking{
+			RoomID: roomID,
+			RoomData: models.RoomData{
+				Date:     date,
+				IsBooked: booked[date],
+				Rate:     opts.DefaultRate,
+			},
+		})
+	}
+
+	if err := s.repo.UpsertBookingStatus(days, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("failed to update availability: %v", err)
+	}
+
+	return report, nil
+}
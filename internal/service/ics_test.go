@@ -0,0 +1,66 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// icsFeed wraps VEVENTs given as DTSTART and DTEND dates into a calendar.
+func icsFeed(stays ...[2]string) string {
+	feed := "BEGIN:VCALENDAR\r\n"
+	for i, stay := range stays {
+		feed += fmt.Sprintf("BEGIN:VEVENT\r\nUID:stay-%d\r\nDTSTART;VALUE=DATE:%s\r\nDTEND;VALUE=DATE:%s\r\nEND:VEVENT\r\n",
+			i, stay[0], stay[1])
+	}
+	return feed + "END:VCALENDAR\r\n"
+}
+
+func TestIngestICS(t *testing.T) {
+	window := ICSOptions{From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		roomID     string
+		feed       string
+		wantBooked int
+		wantErr    error
+	}{
+		{
+			name:       "stays are clamped to the window",
+			roomID:     "R1",
+			feed:       icsFeed([2]string{"20250201", "20250303"}, [2]string{"20250309", "20250401"}),
+			wantBooked: 4,
+		},
+		{
+			name:    "events longer than a year are rejected",
+			roomID:  "R1",
+			feed:    icsFeed([2]string{"20250101", "20260103"}),
+			wantErr: ErrInvalidImport,
+		},
+		{
+			name:    "too long room ID",
+			roomID:  strings.Repeat("R", models.MaxRoomIDLength+1),
+			feed:    icsFeed(),
+			wantErr: ErrInvalidImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFixedService(t, newMemoryRepository(t, nil), "2025-03-01")
+
+			report, err := s.IngestICS(tt.roomID, strings.NewReader(tt.feed), window)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("IngestICS() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (report.BookedDays != tt.wantBooked || report.AvailableDays != 10-tt.wantBooked) {
+				t.Errorf("booked, available days = %d, %d, want %d, %d",
+					report.BookedDays, report.AvailableDays, tt.wantBooked, 10-tt.wantBooked)
+			}
+		})
+	}
+}
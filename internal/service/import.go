@@ -0,0 +1,80 @@
+package service
+
+import (
+	"airbnb-analytics/internal/importer"
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidImport is returned when import input cannot be processed at all
+var ErrInvalidImport = errors.New("invalid import data")
+
+// ImportBookingsCSV validates booking calendar rows in CSV form and upserts
+// the valid ones in a single transaction. Invalid rows are skipped and
+// reported individually.
+// Parameters:
+//   - reader io.Reader: CSV data with rows room_id,date,is_booked,rate
+//
+// Returns:
+//   - *models.ImportReport: Counts of imported and rejected rows with per-row errors
+//   - error: ErrInvalidImport if the input is unreadable, or any storage error
+func (s *RoomService) ImportBookingsCSV(reader io.Reader) (*models.ImportReport, error) {
+	bookings, rowErrors, err := importer.ParseCSV(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	report := &models.ImportReport{
+		RowsRead: len(bookings) + len(rowErrors),
+		Rejected: len(rowErrors),
+		Errors:   rowErrors,
+	}
+	if report.Errors == nil {
+		report.Errors = []models.ImportRowError{}
+	}
+
+	if len(bookings) > 0 {
+		if err := s.repo.UpsertBookings(bookings, s.clock.Now()); err != nil {
+			return nil, fmt.Errorf("failed to import bookings: %v", err)
+		}
+	}
+	report.Imported = len(bookings)
+
+	return report, nil
+}
+
+// ImportExchangeRatesCSV validates exchange rate rows in CSV form and upserts
+// the valid ones in a single transaction. Invalid rows are skipped and
+// reported individually.
+// Parameters:
+//   - reader io.Reader: CSV data with rows currency,date,rate
+//
+// Returns:
+//   - *models.ImportReport: Counts of imported and rejected rows with per-row errors
+//   - error: ErrInvalidImport if the input is unreadable, or any storage error
+func (s *RoomService) ImportExchangeRatesCSV(reader io.Reader) (*models.ImportReport, error) {
+	rates, rowErrors, err := importer.ParseExchangeRatesCSV(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	report := &models.ImportReport{
+		RowsRead: len(rates) + len(rowErrors),
+		Rejected: len(rowErrors),
+		Errors:   rowErrors,
+	}
+	if report.Errors == nil {
+		report.Errors = []models.ImportRowError{}
+	}
+
+	if len(rates) > 0 {
+		if err := s.repo.UpsertExchangeRates(rates); err != nil {
+			return nil, fmt.Errorf("failed to import exchange rates: %v", err)
+		}
+	}
+	report.Imported = len(rates)
+
+	return report, nil
+}
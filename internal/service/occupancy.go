@@ -7,29 +7,29 @@ import (
 )
 
 // calculateMonthlyOccupancy processes room data to calculate occupancy rates
-// for the next 5 months.
+// and revenue metrics for each month within the given date range.
 // Parameters:
 //   - data []models.RoomData: Slice of room booking data
+//   - start time.Time: First day of the occupancy window
+//   - end time.Time: Last day of the occupancy window (inclusive)
 //
 // Returns:
 //   - []models.MonthlyOccupancy: Slice of monthly occupancy statistics
-func calculateMonthlyOccupancy(data []models.RoomData) []models.MonthlyOccupancy {
+func calculateMonthlyOccupancy(data []models.RoomData, start, end time.Time) []models.MonthlyOccupancy {
 	monthlyStats := make(map[string]struct {
-		booked int
-		total  int
+		booked  int
+		total   int
+		revenue float64
 	})
 
-	currentTime := time.Now().Truncate(24 * time.Hour) // Start from beginning of today
-	fiveMonthsFromNow := currentTime.AddDate(0, 4, 0)
-
 	// Calculate monthly statistics
 	for _, booking := range data {
-		bookingDate, err := time.Parse("2006-01-02", booking.Date)
+		bookingDate, err := time.Parse(dateLayout, booking.Date)
 		if err != nil {
 			continue
 		}
 
-		if bookingDate.Before(currentTime) || bookingDate.After(fiveMonthsFromNow) {
+		if !inRange(bookingDate, start, end) {
 			continue
 		}
 
@@ -38,6 +38,7 @@ func calculateMonthlyOccupancy(data []models.RoomData) []models.MonthlyOccupancy
 		stats.total++
 		if booking.IsBooked {
 			stats.booked++
+			stats.revenue += booking.Rate
 		}
 		monthlyStats[month] = stats
 	}
@@ -46,10 +47,18 @@ func calculateMonthlyOccupancy(data []models.RoomData) []models.MonthlyOccupancy
 	var occupancy []models.MonthlyOccupancy
 	for month, stats := range monthlyStats {
 		percent := (float64(stats.booked) / float64(stats.total)) * 100
-		occupancy = append(occupancy, models.MonthlyOccupancy{
+		monthly := models.MonthlyOccupancy{
 			Month:               month,
 			OccupancyPercentage: round(percent),
-		})
+			BookedNights:        stats.booked,
+			TotalNights:         stats.total,
+			Revenue:             round(stats.revenue),
+			RevPAR:              round(stats.revenue / float64(stats.total)),
+		}
+		if stats.booked > 0 {
+			monthly.ADR = round(stats.revenue / float64(stats.booked))
+		}
+		occupancy = append(occupancy, monthly)
 	}
 
 	// Sort by month
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// maxPacePoints limits the number of lead times per pace request
+	maxPacePoints = 12
+	// maxPaceDaysOut limits how far before the target month pace is measured
+	maxPaceDaysOut = 365
+)
+
+// DefaultPaceDaysOut are the lead times reported when none are requested
+var DefaultPaceDaysOut = []int{90, 60, 30, 14, 7, 0}
+
+// PaceOptions describes a requested booking pace report.
+// Zero values select the defaults.
+type PaceOptions struct {
+	// Month is any day of the target month, defaults to next month
+	Month time.Time
+	// DaysOut lists the lead times before the first night of the month,
+	// defaults to DefaultPaceDaysOut
+	DaysOut []int
+}
+
+// GetBookingPace reports the on-the-books occupancy of a target month at
+// several points before its first night, compared with the same points for
+// the same month one year earlier. It is built on the recorded booking history,
+// so nights booked before history was recorded count from the time they were
+// first recorded.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts PaceOptions: Target month and lead times
+//
+// Returns:
+//   - *models.BookingPace: Pace points ordered by descending lead time
+//   - error: ErrInvalidOptions, ErrRoomNotFound or ErrInsufficientHistory, or
+//     any error encountered while fetching the history
+func (s *RoomService) GetBookingPace(roomID string, opts PaceOptions) (*models.BookingPace, error) {
+	today, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	month := opts.Month
+	if month.IsZero() {
+		month = today.AddDate(0, 1, 0)
+	}
+	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastYearStart := monthStart.AddDate(-1, 0, 0)
+
+	daysOut, err := resolveDaysOut(opts.DaysOut)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetBookingHistory(roomID, monthStart, monthStart.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking history: %v", err)
+	}
+	lastYear, err := s.repo.GetBookingHistory(roomID, lastYearStart, lastYearStart.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking history: %v", err)
+	}
+	if len(current) == 0 && len(lastYear) == 0 {
+		if err := s.requireRoom(roomID); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: no history was recorded for %s or a year earlier", ErrInsufficientHistory, monthStart.Format("2006-01"))
+	}
+
+	pace := &models.BookingPace{
+		RoomID:        roomID,
+		Month:         monthStart.Format("2006-01"),
+		LastYearMonth: lastYearStart.Format("2006-01"),
+		Points:        make([]models.PacePoint, 0, len(daysOut)),
+	}
+
+	for _, days := range daysOut {
+		point := models.PacePoint{DaysOut: days}
+
+		cutoff := monthStart.AddDate(0, 0, -days)
+		if !cutoff.After(today) {
+			point.Current = onTheBooks(current, monthStart, cutoff)
+		}
+		if len(lastYear) > 0 {
+			point.LastYear = onTheBooks(lastYear, lastYearStart, lastYearStart.AddDate(0, 0, -days))
+		}
+
+		if point.Current != nil && point.LastYear != nil {
+			occupancyChange := round(point.Current.OccupancyPercentage - point.LastYear.OccupancyPercentage)
+			bookedChange := point.Current.BookedNights - point.LastYear.BookedNights
+			point.OccupancyChange = &occupancyChange
+			point.BookedNightsChange = &bookedChange
+		}
+
+		pace.Points = append(pace.Points, point)
+	}
+
+	return pace, nil
+}
+
+// resolveDaysOut validates the requested lead times.
+// Parameters:
+//   - daysOut []int: Requested lead times, nil selects DefaultPaceDaysOut
+//
+// Returns:
+//   - []int: De-duplicated lead times in descending order
+//   - error: ErrInvalidOptions wrapped with details when validation fails
+func resolveDaysOut(daysOut []int) ([]int, error) {
+	if len(daysOut) == 0 {
+		return DefaultPaceDaysOut, nil
+	}
+	if len(daysOut) > maxPacePoints {
+		return nil, fmt.Errorf("%w: at most %d lead times can be requested", ErrInvalidOptions, maxPacePoints)
+	}
+
+	seen := make(map[int]bool)
+	var resolved []int
+	for _, days := range daysOut {
+		if days < 0 || days > maxPaceDaysOut {
+			return nil, fmt.Errorf("%w: days_out must be between 0 and %d", ErrInvalidOptions, maxPaceDaysOut)
+		}
+		if !seen[days] {
+			seen[days] = true
+			resolved = append(resolved, days)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(resolved)))
+
+	return resolved, nil
+}
+
+// onTheBooks reconstructs the bookings of a month as they were at the end of
+// a cutoff day. Nights without a recorded state by then count as not booked.
+// Parameters:
+//   - changes []models.BookingChange: History of the month ordered by date and time of change
+//   - monthStart time.Time: First day of the month
+//   - cutoff time.Time: Last day whose changes are included
+//
+// Returns:
+//   - *models.PaceSnapshot: Bookings on the books at the cutoff
+func onTheBooks(changes []models.BookingChange, monthStart, cutoff time.Time) *models.PaceSnapshot {
+	cutoffEnd := cutoff.AddDate(0, 0, 1)
+
+	states := make(map[string]models.RoomData)
+	for _, change := range changes {
+		if change.ChangedAt.Before(cutoffEnd) {
+			states[change.Date] = change.RoomData
+		}
+	}
+
+	snapshot := &models.PaceSnapshot{
+		Cutoff:      cutoff.Format(dateLayout),
+		TotalNights: monthStart.AddDate(0, 1, -1).Day(),
+	}
+
+	var revenue float64
+	for _, state := range states {
+		if state.IsBooked {
+			snapshot.BookedNights++
+			revenue += state.Rate
+		}
+	}
+
+	snapshot.OccupancyPercentage = round(float64(snapshot.BookedNights) / float64(snapshot.TotalNights) * 100)
+	snapshot.Revenue = round(revenue)
+	if snapshot.BookedNights > 0 {
+		snapshot.ADR = round(revenue / float64(snapshot.BookedNights))
+	}
+
+	return snapshot
+}
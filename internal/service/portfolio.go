@@ -0,0 +1,159 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PortfolioOptions controls the rooms and date ranges used by GetPortfolioAnalytics.
+type PortfolioOptions struct {
+	AnalyticsOptions
+	// RoomIDs restricts the portfolio to the listed rooms, all rooms if empty
+	RoomIDs []string
+	// MaxGap is the longest orphan gap counted per room, defaults to DefaultMaxGap
+	MaxGap int
+}
+
+// GetPortfolioAnalytics computes occupancy and rate analytics across several
+// rooms from a single repository query, along with per-room rankings and
+// orphan gap counts. Rates are converted to opts.Currency if given; otherwise
+// all rooms must share one currency, as rates in different currencies can't
+// be aggregated.
+// Parameters:
+//   - opts PortfolioOptions: Rooms and analysis windows, zero values select defaults
+//
+// Returns:
+//   - *models.PortfolioAnalytics: Aggregated analytics and room rankings
+//   - error: ErrInvalidOptions if the rooms use different currencies and no
+//     currency is given, or any error encountered during data retrieval or processing
+func (s *RoomService) GetPortfolioAnalytics(opts PortfolioOptions) (*models.PortfolioAnalytics, error) {
+	asOf := s.today()
+	if !opts.AsOf.IsZero() {
+		asOf = truncateToDay(opts.AsOf)
+	}
+
+	window, err := resolveWindow(opts.AnalyticsOptions, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	percentiles, err := resolvePercentiles(opts.Percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	maxGap, err := resolveMaxGap(opts.MaxGap)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := resolveCurrency(opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate := window.fetchRange()
+	bookings, err := s.repo.GetBookings(opts.RoomIDs, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings: %v", err)
+	}
+
+	if currency != "" {
+		if err := s.convertBookings(bookings, currency, startDate, endDate); err != nil {
+			return nil, err
+		}
+	} else if currency, err = s.commonCurrency(bookings); err != nil {
+		return nil, err
+	}
+
+	// Group the single result set by room, keeping repository order
+	var roomIDs []string
+	byRoom := make(map[string][]models.RoomData)
+	all := make([]models.RoomData, 0, len(bookings))
+	for _, booking := range bookings {
+		if _, ok := byRoom[booking.RoomID]; !ok {
+			roomIDs = append(roomIDs, booking.RoomID)
+		}
+		byRoom[booking.RoomID] = append(byRoom[booking.RoomID], booking.RoomData)
+		all = append(all, booking.RoomData)
+	}
+
+	rooms := make([]models.PortfolioRoom, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		data := byRoom[roomID]
+		gaps := analyzeGaps(data, window.occupancyStart, window.occupancyEnd, maxGap)
+		rooms = append(rooms, models.PortfolioRoom{
+			RoomID:              roomID,
+			OccupancyPercentage: occupancyPercentage(data, window.occupancyStart, window.occupancyEnd),
+			AverageRate:         calculateRateAnalytics(data, window.rateStart, window.rateEnd, nil).AverageRate,
+			OrphanGaps:          gaps.OrphanGapCount,
+			OrphanNights:        gaps.OrphanNights,
+		})
+	}
+	rankRooms(rooms)
+
+	occupancy := calculateMonthlyOccupancy(all, window.occupancyStart, window.occupancyEnd)
+	rateAnalytics := calculateRateAnalytics(all, window.rateStart, window.rateEnd, percentiles)
+	addComparisons(all, window, occupancy, &rateAnalytics)
+
+	return &models.PortfolioAnalytics{
+		Currency:         currency,
+		Window:           window.toModel(),
+		RoomCount:        len(rooms),
+		MonthlyOccupancy: occupancy,
+		RateAnalytics:    rateAnalytics,
+		Rooms:            rooms,
+	}, nil
+}
+
+// occupancyPercentage calculates the share of booked days within a date range.
+// Parameters:
+//   - data []models.RoomData: Slice of room booking data
+//   - start time.Time: First day of the range
+//   - end time.Time: Last day of the range (inclusive)
+//
+// Returns:
+//   - float64: Percentage of booked days, 0 if there are no days in range
+func occupancyPercentage(data []models.RoomData, start, end time.Time) float64 {
+	var booked, total int
+	for _, booking := range data {
+		bookingDate, err := time.Parse(dateLayout, booking.Date)
+		if err != nil || !inRange(bookingDate, start, end) {
+			continue
+		}
+		total++
+		if booking.IsBooked {
+			booked++
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return round(float64(booked) / float64(total) * 100)
+}
+
+// rankRooms assigns occupancy and rate ranks and orders rooms by occupancy.
+// Ties share the order of their room IDs.
+// Parameters:
+//   - rooms []models.PortfolioRoom: Rooms to rank in place
+func rankRooms(rooms []models.PortfolioRoom) {
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].AverageRate > rooms[j].AverageRate
+	})
+	for i := range rooms {
+		rooms[i].RateRank = i + 1
+	}
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].OccupancyPercentage != rooms[j].OccupancyPercentage {
+			return rooms[i].OccupancyPercentage > rooms[j].OccupancyPercentage
+		}
+		return rooms[i].RoomID < rooms[j].RoomID
+	})
+	for i := range rooms {
+		rooms[i].OccupancyRank = i + 1
+	}
+}
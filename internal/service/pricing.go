@@ -0,0 +1,311 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultSuggestionDays is the number of days priced when no end date is requested
+	defaultSuggestionDays = 30
+	// maxSuggestionDays limits the number of days priced per request
+	maxSuggestionDays = 366
+	// pricingHistoryDays is the number of days before as_of used as history
+	pricingHistoryDays = 365
+	// minPricingHistory is the minimum number of historical nights needed to price
+	minPricingHistory = 30
+	// minFactorNights is the minimum number of nights backing a demand factor
+	minFactorNights = 4
+	// minRateLevelNights is the minimum number of nights backing a rate level
+	minRateLevelNights = 7
+	// demandSensitivity scales occupancy differences into rate adjustments
+	demandSensitivity = 0.5
+	// minFactor and maxFactor bound every single adjustment factor
+	minFactor = 0.8
+	maxFactor = 1.2
+)
+
+// leadTimeFactor adjusts rates by the number of days until arrival.
+type leadTimeFactor struct {
+	maxDays int
+	factor  float64
+}
+
+// leadTimeFactors lists lead time adjustments by ascending lead time.
+// Unbooked nights close to arrival are discounted to fill them, nights far
+// ahead carry a small premium as they still have time to sell.
+var leadTimeFactors = []leadTimeFactor{
+	{maxDays: 3, factor: 0.85},
+	{maxDays: 7, factor: 0.9},
+	{maxDays: 14, factor: 0.95},
+	{maxDays: 60, factor: 1},
+}
+
+// farLeadTimeFactor applies beyond the last lead time bucket
+const farLeadTimeFactor = 1.05
+
+// PriceSuggestionOptions describes the days to suggest rates for.
+// Zero values select the defaults.
+type PriceSuggestionOptions struct {
+	// AsOf is the date suggestions are made on, defaults to today
+	AsOf time.Time
+	// From is the first day to price, defaults to AsOf and may not be earlier
+	From time.Time
+	// To is the last day to price, defaults to 30 days starting at From
+	To time.Time
+}
+
+// pricingModel holds the demand statistics derived from a room's history.
+type pricingModel struct {
+	baseRate   float64
+	priceLevel float64
+	occupancy  float64
+	byWeekday  [7]nightTally
+	byMonth    [12]nightTally
+}
+
+// SuggestPrices suggests rates for the future unbooked days of a room.
+// Each suggestion starts from the median historical rate and applies factors
+// for the best performing rate level, day of week, seasonality and lead time.
+// History covers the 365 days before opts.AsOf.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts PriceSuggestionOptions: Days to price
+//
+// Returns:
+//   - *models.PriceSuggestions: Suggested rates with the factors applied per day
+//   - error: ErrInvalidWindow, ErrRoomNotFound or ErrInsufficientHistory
+//     wrapped with details, or any error encountered while fetching data
+func (s *RoomService) SuggestPrices(roomID string, opts PriceSuggestionOptions) (*models.PriceSuggestions, error) {
+	asOf, err := s.roomToday(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.AsOf.IsZero() {
+		asOf = truncateToDay(opts.AsOf)
+	}
+
+	from := asOf
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+	to := from.AddDate(0, 0, defaultSuggestionDays-1)
+	if !opts.To.IsZero() {
+		to = truncateToDay(opts.To)
+	}
+
+	if from.Before(asOf) {
+		return nil, fmt.Errorf("%w: from must not be before as_of", ErrInvalidWindow)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to must not be before from", ErrInvalidWindow)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxSuggestionDays {
+		return nil, fmt.Errorf("%w: at most %d days can be priced at once", ErrInvalidWindow, maxSuggestionDays)
+	}
+
+	historyStart := asOf.AddDate(0, 0, -pricingHistoryDays)
+	historyEnd := asOf.AddDate(0, 0, -1)
+
+	roomData, err := s.repo.GetRoomData(roomID, historyStart, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room data: %v", err)
+	}
+	if len(roomData) == 0 {
+		// Rooms with a record but no calendar fail the history check below
+		if err := s.requireRoom(roomID); err != nil {
+			return nil, err
+		}
+	}
+
+	var history, future []models.RoomData
+	for _, day := range roomData {
+		date, err := time.Parse(dateLayout, day.Date)
+		if err != nil {
+			continue
+		}
+		switch {
+		case inRange(date, historyStart, historyEnd):
+			history = append(history, day)
+		case inRange(date, from, to) && !day.IsBooked:
+			future = append(future, day)
+		}
+	}
+
+	if len(history) < minPricingHistory {
+		return nil, fmt.Errorf("%w: at least %d nights of history are required", ErrInsufficientHistory, minPricingHistory)
+	}
+
+	model, levels := buildPricingModel(history)
+
+	suggestions := &models.PriceSuggestions{
+		RoomID:      roomID,
+		AsOf:        asOf.Format(dateLayout),
+		From:        from.Format(dateLayout),
+		To:          to.Format(dateLayout),
+		HistoryFrom: historyStart.Format(dateLayout),
+		HistoryTo:   historyEnd.Format(dateLayout),
+		BaseRate:    round(model.baseRate),
+		RateLevels:  levels,
+		Days:        make([]models.PriceSuggestion, 0, len(future)),
+	}
+
+	for _, day := range future {
+		date, _ := time.Parse(dateLayout, day.Date)
+		suggestions.Days = append(suggestions.Days, model.suggest(day, date, asOf))
+	}
+
+	return suggestions, nil
+}
+
+// buildPricingModel derives demand statistics from historical nights.
+// Parameters:
+//   - history []models.RoomData: Historical nights, must not be empty
+//
+// Returns:
+//   - pricingModel: Base rate and demand tallies
+//   - []models.RateLevel: Occupancy achieved at each historical rate level
+func buildPricingModel(history []models.RoomData) (pricingModel, []models.RateLevel) {
+	model := pricingModel{priceLevel: 1}
+
+	var overall nightTally
+	rates := make([]float64, 0, len(history))
+	for _, day := range history {
+		date, _ := time.Parse(dateLayout, day.Date)
+		overall.add(day)
+		model.byWeekday[date.Weekday()].add(day)
+		model.byMonth[date.Month()-1].add(day)
+		rates = append(rates, day.Rate)
+	}
+	sort.Float64s(rates)
+
+	model.baseRate = percentile(rates, 50)
+	model.occupancy = overall.occupancy()
+
+	levels := rateLevels(history, rates)
+	bestRevenue := -1.0
+	for _, level := range levels {
+		if level.Nights >= minRateLevelNights && level.ExpectedRevenue > bestRevenue && model.baseRate > 0 {
+			bestRevenue = level.ExpectedRevenue
+			model.priceLevel = clampFactor(level.AverageRate / model.baseRate)
+		}
+	}
+
+	return model, levels
+}
+
+// rateLevels groups historical nights into rate quartiles and reports the
+// occupancy and expected revenue per night achieved at each level.
+// Parameters:
+//   - history []models.RoomData: Historical nights
+//   - sortedRates []float64: Rates of all historical nights in ascending order
+//
+// Returns:
+//   - []models.RateLevel: Rate levels from cheapest to most expensive
+func rateLevels(history []models.RoomData, sortedRates []float64) []models.RateLevel {
+	var bounds []float64
+	for _, p := range []float64{0, 25, 50, 75} {
+		bound := percentile(sortedRates, p)
+		if len(bounds) == 0 || bound > bounds[len(bounds)-1] {
+			bounds = append(bounds, bound)
+		}
+	}
+
+	tallies := make([]nightTally, len(bounds))
+	maxRates := make([]float64, len(bounds))
+	for _, day := range history {
+		level := sort.Search(len(bounds), func(i int) bool { return bounds[i] > day.Rate }) - 1
+		if level < 0 {
+			level = 0
+		}
+		tallies[level].add(day)
+		maxRates[level] = math.Max(maxRates[level], day.Rate)
+	}
+
+	levels := make([]models.RateLevel, 0, len(bounds))
+	for i, tally := range tallies {
+		if tally.nights == 0 {
+			continue
+		}
+		averageRate := tally.rates / float64(tally.nights)
+		levels = append(levels, models.RateLevel{
+			MinRate:         round(bounds[i]),
+			MaxRate:         round(maxRates[i]),
+			NightStats:      tally.stats(),
+			ExpectedRevenue: round(averageRate * tally.occupancy() / 100),
+		})
+	}
+	return levels
+}
+
+// suggest prices a single unbooked day.
+// Parameters:
+//   - day models.RoomData: Day to price
+//   - date time.Time: Parsed date of the day
+//   - asOf time.Time: Date the suggestion is made on
+//
+// Returns:
+//   - models.PriceSuggestion: Suggested rate with the factors applied
+func (m pricingModel) suggest(day models.RoomData, date, asOf time.Time) models.PriceSuggestion {
+	leadDays := int(date.Sub(asOf).Hours() / 24)
+
+	factors := models.PriceFactors{
+		BaseRate:    round(m.baseRate),
+		PriceLevel:  round(m.priceLevel),
+		DayOfWeek:   round(m.demandFactor(m.byWeekday[date.Weekday()])),
+		Seasonality: round(m.demandFactor(m.byMonth[date.Month()-1])),
+		LeadTime:    leadTimeAdjustment(leadDays),
+	}
+
+	rate := m.baseRate * factors.PriceLevel * factors.DayOfWeek * factors.Seasonality * factors.LeadTime
+
+	return models.PriceSuggestion{
+		Date:             day.Date,
+		CurrentRate:      day.Rate,
+		SuggestedRate:    round(math.Min(rate, models.MaxRate)),
+		DaysUntilArrival: leadDays,
+		Factors:          factors,
+	}
+}
+
+// demandFactor converts the occupancy of a group of nights relative to the
+// overall occupancy into a rate adjustment.
+// Parameters:
+//   - tally nightTally: Historical nights of the group
+//
+// Returns:
+//   - float64: Adjustment factor, 1 if the group has too few nights
+func (m pricingModel) demandFactor(tally nightTally) float64 {
+	if tally.nights < minFactorNights {
+		return 1
+	}
+	return clampFactor(1 + demandSensitivity*(tally.occupancy()-m.occupancy)/100)
+}
+
+// leadTimeAdjustment looks up the lead time factor for a number of days until arrival.
+// Parameters:
+//   - days int: Days between the suggestion date and the night
+//
+// Returns:
+//   - float64: Adjustment factor
+func leadTimeAdjustment(days int) float64 {
+	for _, bucket := range leadTimeFactors {
+		if days <= bucket.maxDays {
+			return bucket.factor
+		}
+	}
+	return farLeadTimeFactor
+}
+
+// clampFactor bounds an adjustment factor to [minFactor, maxFactor].
+// Parameters:
+//   - factor float64: Factor to clamp
+//
+// Returns:
+//   - float64: Clamped factor
+func clampFactor(factor float64) float64 {
+	return math.Max(minFactor, math.Min(maxFactor, factor))
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// repeatNights builds a history of nights on consecutive days starting at
+// 2025-01-01 that cycles through rates, booking the nights whose rate is booked.
+func repeatNights(rates []float64, booked map[float64]bool, cycles int) []models.RoomData {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var history []models.RoomData
+	for i := 0; i < cycles*len(rates); i++ {
+		rate := rates[i%len(rates)]
+		history = append(history, models.RoomData{
+			Date:     start.AddDate(0, 0, i).Format(dateLayout),
+			IsBooked: booked[rate],
+			Rate:     rate,
+		})
+	}
+	return history
+}
+
+func TestRateLevels(t *testing.T) {
+	level := func(minRate, maxRate float64, nights, booked int, occupancy, averageRate, revenue float64) models.RateLevel {
+		return models.RateLevel{
+			MinRate: minRate,
+			MaxRate: maxRate,
+			NightStats: models.NightStats{
+				Nights:              nights,
+				BookedNights:        booked,
+				OccupancyPercentage: occupancy,
+				AverageRate:         averageRate,
+			},
+			ExpectedRevenue: revenue,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		history []models.RoomData
+		want    []models.RateLevel
+	}{
+		{
+			name:    "quartiles split evenly spread rates",
+			history: repeatNights([]float64{10, 20, 30, 40, 50, 60, 70, 80}, map[float64]bool{10: true, 30: true, 40: true, 50: true, 80: true}, 1),
+			want: []models.RateLevel{
+				level(10, 20, 2, 1, 50, 15, 7.5),
+				level(27.5, 40, 2, 2, 100, 35, 35),
+				level(45, 60, 2, 1, 50, 55, 27.5),
+				level(62.5, 80, 2, 1, 50, 75, 37.5),
+			},
+		},
+		{
+			name:    "equal quartile bounds are merged",
+			history: repeatNights([]float64{100, 100, 100, 200}, map[float64]bool{200: true}, 1),
+			want: []models.RateLevel{
+				level(100, 100, 3, 0, 0, 100, 0),
+				level(125, 200, 1, 1, 100, 200, 200),
+			},
+		},
+		{
+			name:    "a single rate forms a single level",
+			history: repeatNights([]float64{100}, map[float64]bool{100: true}, 4),
+			want: []models.RateLevel{
+				level(100, 100, 4, 4, 100, 100, 100),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates := make([]float64, 0, len(tt.history))
+			for _, day := range tt.history {
+				rates = append(rates, day.Rate)
+			}
+			sort.Float64s(rates)
+
+			if got := rateLevels(tt.history, rates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rateLevels() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPricingModelPriceLevel(t *testing.T) {
+	tests := []struct {
+		name         string
+		history      []models.RoomData
+		wantBaseRate float64
+		wantLevel    float64
+	}{
+		{
+			name:         "moves towards the level with the highest expected revenue",
+			history:      repeatNights([]float64{90, 92, 94, 96, 98, 100, 102, 104}, map[float64]bool{102: true, 104: true}, 7),
+			wantBaseRate: 97,
+			wantLevel:    1.06,
+		},
+		{
+			name:         "is clamped to the maximum factor",
+			history:      repeatNights([]float64{10, 20, 30, 40, 50, 60, 70, 80}, map[float64]bool{70: true, 80: true}, 7),
+			wantBaseRate: 45,
+			wantLevel:    maxFactor,
+		},
+		{
+			name:         "is clamped to the minimum factor",
+			history:      repeatNights([]float64{10, 20, 30, 40, 50, 60, 70, 80}, map[float64]bool{10: true, 20: true}, 7),
+			wantBaseRate: 45,
+			wantLevel:    minFactor,
+		},
+		{
+			name:         "ignores levels with too few nights",
+			history:      repeatNights([]float64{10, 20, 30, 40, 50, 60, 70, 80}, map[float64]bool{70: true, 80: true}, 3),
+			wantBaseRate: 45,
+			wantLevel:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, _ := buildPricingModel(tt.history)
+			if round(model.baseRate) != tt.wantBaseRate {
+				t.Errorf("baseRate = %v, want %v", model.baseRate, tt.wantBaseRate)
+			}
+			if round(model.priceLevel) != tt.wantLevel {
+				t.Errorf("priceLevel = %v, want %v", model.priceLevel, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestDemandFactor(t *testing.T) {
+	model := pricingModel{occupancy: 50}
+
+	tests := []struct {
+		name  string
+		tally nightTally
+		want  float64
+	}{
+		{name: "too few nights", tally: nightTally{nights: minFactorNights - 1, booked: minFactorNights - 1}, want: 1},
+		{name: "average demand", tally: nightTally{nights: 10, booked: 5}, want: 1},
+		{name: "higher demand", tally: nightTally{nights: 10, booked: 6}, want: 1.05},
+		{name: "lower demand", tally: nightTally{nights: 10, booked: 3}, want: 0.9},
+		{name: "clamped high", tally: nightTally{nights: 10, booked: 10}, want: maxFactor},
+		{name: "clamped low", tally: nightTally{nights: 10, booked: 0}, want: minFactor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := round(model.demandFactor(tt.tally)); got != tt.want {
+				t.Errorf("demandFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeadTimeAdjustment(t *testing.T) {
+	tests := []struct {
+		days int
+		want float64
+	}{
+		{days: 0, want: 0.85},
+		{days: 3, want: 0.85},
+		{days: 4, want: 0.9},
+		{days: 7, want: 0.9},
+		{days: 8, want: 0.95},
+		{days: 14, want: 0.95},
+		{days: 15, want: 1},
+		{days: 60, want: 1},
+		{days: 61, want: farLeadTimeFactor},
+	}
+
+	for _, tt := range tests {
+		if got := leadTimeAdjustment(tt.days); got != tt.want {
+			t.Errorf("leadTimeAdjustment(%d) = %v, want %v", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestPrices(t *testing.T) {
+	asOf := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// A year of fully booked nights at a flat rate leaves only the lead time to vary
+	var bookings []models.RoomBooking
+	for day := asOf.AddDate(0, 0, -pricingHistoryDays); day.Before(asOf); day = day.AddDate(0, 0, 1) {
+		bookings = append(bookings, models.RoomBooking{RoomID: "R1", RoomData: models.RoomData{Date: day.Format(dateLayout), IsBooked: true, Rate: 100}})
+	}
+	for _, future := range []struct {
+		date   string
+		booked bool
+	}{
+		{"2025-06-01", false},
+		{"2025-06-02", true},
+		{"2025-06-05", false},
+		{"2025-06-10", false},
+		{"2025-06-20", false},
+		{"2025-08-15", false},
+	} {
+		bookings = append(bookings, models.RoomBooking{RoomID: "R1", RoomData: models.RoomData{Date: future.date, IsBooked: future.booked, Rate: 120}})
+	}
+	bookings = append(bookings, models.RoomBooking{RoomID: "SHORT", RoomData: models.RoomData{Date: "2025-05-31", Rate: 100}})
+
+	repo := newMemoryRepository(t, bookings)
+	if err := repo.CreateRoom(models.Room{RoomID: "EMPTY"}); err != nil {
+		t.Fatalf("creating room: %v", err)
+	}
+	s := newFixedService(t, repo, asOf.Format(dateLayout))
+
+	t.Run("suggestions follow the lead time", func(t *testing.T) {
+		suggestions, err := s.SuggestPrices("R1", PriceSuggestionOptions{To: time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("SuggestPrices() error = %v", err)
+		}
+
+		want := map[string]float64{
+			"2025-06-01": 85,
+			"2025-06-05": 90,
+			"2025-06-10": 95,
+			"2025-06-20": 100,
+			"2025-08-15": 105,
+		}
+		got := make(map[string]float64)
+		for _, day := range suggestions.Days {
+			got[day.Date] = day.SuggestedRate
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("suggested rates = %v, want %v", got, want)
+		}
+		if suggestions.BaseRate != 100 {
+			t.Errorf("BaseRate = %v, want 100", suggestions.BaseRate)
+		}
+	})
+
+	errorTests := []struct {
+		name   string
+		roomID string
+		opts   PriceSuggestionOptions
+		want   error
+	}{
+		{name: "unknown room", roomID: "MISSING", want: ErrRoomNotFound},
+		{name: "room without history", roomID: "EMPTY", want: ErrInsufficientHistory},
+		{name: "short history", roomID: "SHORT", want: ErrInsufficientHistory},
+		{name: "from before as of", roomID: "R1", opts: PriceSuggestionOptions{From: asOf.AddDate(0, 0, -1)}, want: ErrInvalidWindow},
+		{name: "too many days", roomID: "R1", opts: PriceSuggestionOptions{To: asOf.AddDate(0, 0, maxSuggestionDays)}, want: ErrInvalidWindow},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.SuggestPrices(tt.roomID, tt.opts); !errors.Is(err, tt.want) {
+				t.Errorf("SuggestPrices() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
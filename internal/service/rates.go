@@ -2,30 +2,39 @@ package service
 
 import (
 	"airbnb-analytics/internal/models"
+	"sort"
 	"time"
 )
 
-// calculateRateAnalytics processes room data to calculate rate statistics
-// for the next 30 days.
+// calculateRateAnalytics processes room data to calculate rate statistics,
+// the rate distribution and revenue metrics for the given date range.
+// Rate statistics consider all nights; ADR considers booked nights only.
 // Parameters:
 //   - data []models.RoomData: Slice of room booking data
+//   - start time.Time: First day of the rate window
+//   - end time.Time: Last day of the rate window (inclusive)
+//   - percentiles []float64: Percentiles of the rate distribution to report
 //
 // Returns:
 //   - models.RateAnalytics: Calculated rate statistics
-func calculateRateAnalytics(data []models.RoomData) models.RateAnalytics {
+func calculateRateAnalytics(data []models.RoomData, start, end time.Time, percentiles []float64) models.RateAnalytics {
 	var rates []float64
-	currentTime := time.Now().Truncate(24 * time.Hour) // Start from beginning of today
-	thirtyDaysFromNow := currentTime.AddDate(0, 0, 30)
+	var revenue float64
+	var booked int
 
-	// Collect rates for next 30 days
+	// Collect rates within the window
 	for _, booking := range data {
-		bookingDate, err := time.Parse("2006-01-02", booking.Date)
+		bookingDate, err := time.Parse(dateLayout, booking.Date)
 		if err != nil {
 			continue
 		}
 
-		if !bookingDate.Before(currentTime) && !bookingDate.After(thirtyDaysFromNow) {
+		if inRange(bookingDate, start, end) {
 			rates = append(rates, booking.Rate)
+			if booking.IsBooked {
+				booked++
+				revenue += booking.Rate
+			}
 		}
 	}
 
@@ -33,6 +42,7 @@ func calculateRateAnalytics(data []models.RoomData) models.RateAnalytics {
 		AverageRate: 0,
 		HighestRate: 0,
 		LowestRate:  0,
+		Percentiles: map[string]float64{},
 	}
 
 	if len(rates) > 0 {
@@ -50,10 +60,33 @@ func calculateRateAnalytics(data []models.RoomData) models.RateAnalytics {
 			}
 		}
 
+		mean := sum / float64(len(rates))
+		stdDev := standardDeviation(rates, mean)
+
+		sorted := append([]float64(nil), rates...)
+		sort.Float64s(sorted)
+
 		rateAnalytics = models.RateAnalytics{
-			AverageRate: round(sum / float64(len(rates))),
-			HighestRate: round(highest),
-			LowestRate:  round(lowest),
+			AverageRate:       round(mean),
+			HighestRate:       round(highest),
+			LowestRate:        round(lowest),
+			MedianRate:        round(percentile(sorted, 50)),
+			Percentiles:       make(map[string]float64, len(percentiles)),
+			StandardDeviation: round(stdDev),
+			RevPAR:            round(revenue / float64(len(rates))),
+			TotalRevenue:      round(revenue),
+			PotentialRevenue:  round(sum),
+			BookedNights:      booked,
+			TotalNights:       len(rates),
+		}
+		if booked > 0 {
+			rateAnalytics.ADR = round(revenue / float64(booked))
+		}
+		if mean > 0 {
+			rateAnalytics.CoefficientOfVariation = round(stdDev / mean)
+		}
+		for _, p := range percentiles {
+			rateAnalytics.Percentiles[percentileKey(p)] = round(percentile(sorted, p))
 		}
 	}
 
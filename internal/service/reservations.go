@@ -0,0 +1,367 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxReservationNights limits the length of a single stay
+	maxReservationNights = 366
+	// defaultReservationChannel is stored when a reservation names no channel
+	defaultReservationChannel = "direct"
+	// defaultReservationRangeDays is how far before and after today reservations
+	// are listed and analyzed when no range is requested
+	defaultReservationRangeDays = 365
+	// maxReservationRangeDays limits the check-in range of a single request
+	maxReservationRangeDays = 3 * 366
+)
+
+// ErrInvalidReservation is returned when a reservation fails validation
+var ErrInvalidReservation = errors.New("invalid reservation")
+
+// ErrReservationConflict is returned when a reservation overlaps an existing one
+var ErrReservationConflict = errors.New("reservation conflict")
+
+// distributionRange is a labelled category of values up to max (inclusive).
+type distributionRange struct {
+	label string
+	max   int
+}
+
+// lengthOfStayRanges categorizes stays by number of nights
+var lengthOfStayRanges = []distributionRange{
+	{"1", 1}, {"2", 2}, {"3", 3}, {"4-6", 6}, {"7-13", 13}, {"14-27", 27}, {"28+", math.MaxInt},
+}
+
+// leadTimeRanges categorizes reservations by days between booking and check-in
+var leadTimeRanges = []distributionRange{
+	{"0-1", 1}, {"2-7", 7}, {"8-14", 14}, {"15-30", 30}, {"31-60", 60}, {"61-90", 90}, {"91-180", 180}, {"181+", math.MaxInt},
+}
+
+// ReservationOptions selects reservations by check-in date.
+// Zero values select the defaults.
+type ReservationOptions struct {
+	// From is the first check-in day to include, defaults to one year ago
+	From time.Time
+	// To is the last check-in day to include, defaults to one year from the room's today
+	To time.Time
+}
+
+// CreateReservation stores a reservation for a room and books its nights in
+// the daily calendar, spreading the total price evenly over the nights.
+// Parameters:
+//   - roomID string: Room the reservation is for
+//   - input models.ReservationInput: Reservation details
+//
+// Returns:
+//   - *models.Reservation: The stored reservation
+//   - error: ErrInvalidReservation or ErrReservationConflict wrapped with
+//     details, or any storage error
+func (s *RoomService) CreateReservation(roomID string, input models.ReservationInput) (*models.Reservation, error) {
+	if len(roomID) > models.MaxRoomIDLength {
+		return nil, fmt.Errorf("%w: room ID must be at most %d characters", ErrInvalidReservation, models.MaxRoomIDLength)
+	}
+
+	checkIn, err := time.Parse(dateLayout, input.CheckIn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: check_in must be a date in YYYY-MM-DD format", ErrInvalidReservation)
+	}
+	checkOut, err := time.Parse(dateLayout, input.CheckOut)
+	if err != nil {
+		return nil, fmt.Errorf("%w: check_out must be a date in YYYY-MM-DD format", ErrInvalidReservation)
+	}
+
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+	if nights < 1 {
+		return nil, fmt.Errorf("%w: check_out must be after check_in", ErrInvalidReservation)
+	}
+	if nights > maxReservationNights {
+		return nil, fmt.Errorf("%w: a stay can be at most %d nights", ErrInvalidReservation, maxReservationNights)
+	}
+
+	location, err := s.roomLocation(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookedAt := s.clock.Now().UTC()
+	if input.BookedAt != nil {
+		bookedAt = input.BookedAt.UTC()
+	}
+	// Compare with the check-in on the listing's local calendar
+	if truncateToDay(bookedAt.In(location)).After(checkIn) {
+		return nil, fmt.Errorf("%w: booked_at must not be after check_in", ErrInvalidReservation)
+	}
+
+	guests := input.GuestCount
+	if guests == 0 {
+		guests = 1
+	}
+	if guests < 0 {
+		return nil, fmt.Errorf("%w: guest_count must be positive", ErrInvalidReservation)
+	}
+
+	if math.IsNaN(input.TotalPrice) || input.TotalPrice < 0 || input.TotalPrice > models.MaxReservationPrice {
+		return nil, fmt.Errorf("%w: total_price must be between 0 and %.2f", ErrInvalidReservation, models.MaxReservationPrice)
+	}
+	// Spread the price in whole cents; the last night takes the remainder so
+	// the nights add up to the total
+	totalCents := int64(math.Round(input.TotalPrice * 100))
+	nightlyCents := totalCents / int64(nights)
+	lastCents := totalCents - nightlyCents*int64(nights-1)
+	if float64(lastCents)/100 > models.MaxRate {
+		return nil, fmt.Errorf("%w: nightly rate must be at most %.2f", ErrInvalidReservation, models.MaxRate)
+	}
+
+	channel := strings.ToLower(strings.TrimSpace(input.Channel))
+	if channel == "" {
+		channel = defaultReservationChannel
+	}
+	if len(channel) > models.MaxChannelLength {
+		return nil, fmt.Errorf("%w: channel must be at most %d characters", ErrInvalidReservation, models.MaxChannelLength)
+	}
+
+	calendar := make([]models.RoomBooking, 0, nights)
+	for day := checkIn; day.Before(checkOut); day = day.AddDate(0, 0, 1) {
+		rate := float64(nightlyCents) / 100
+		if len(calendar) == nights-1 {
+			rate = float64(lastCents) / 100
+		}
+		calendar = append(calendar, models.RoomBooking{
+			RoomID:   roomID,
+			RoomData: models.RoomData{Date: day.Format(dateLayout), IsBooked: true, Rate: rate},
+		})
+	}
+
+	reservation, err := s.repo.CreateReservation(models.Reservation{
+		RoomID:     roomID,
+		CheckIn:    input.CheckIn,
+		CheckOut:   input.CheckOut,
+		BookedAt:   bookedAt,
+		GuestCount: guests,
+		TotalPrice: input.TotalPrice,
+		Channel:    channel,
+	}, calendar, s.clock.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrReservationOverlap) {
+			return nil, fmt.Errorf("%w: %s to %s overlaps an existing reservation", ErrReservationConflict, input.CheckIn, input.CheckOut)
+		}
+		return nil, fmt.Errorf("failed to create reservation: %v", err)
+	}
+
+	return &reservation, nil
+}
+
+// GetReservations lists the reservations of a room checking in within a range.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts ReservationOptions: Check-in range
+//
+// Returns:
+//   - *models.ReservationList: Reservations ordered by check-in
+//   - error: ErrInvalidWindow wrapped with details, or any storage error
+func (s *RoomService) GetReservations(roomID string, opts ReservationOptions) (*models.ReservationList, error) {
+	from, to, err := s.resolveReservationRange(roomID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.repo.GetReservations(roomID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %v", err)
+	}
+	if reservations == nil {
+		reservations = []models.Reservation{}
+	}
+
+	return &models.ReservationList{
+		RoomID:       roomID,
+		From:         from.Format(dateLayout),
+		To:           to.Format(dateLayout),
+		Reservations: reservations,
+	}, nil
+}
+
+// GetReservationAnalytics calculates length of stay, lead time, check-in day
+// and channel statistics for the reservations of a room checking in within a range.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - opts ReservationOptions: Check-in range
+//
+// Returns:
+//   - *models.ReservationAnalytics: Stay statistics, zero without reservations
+//   - error: ErrInvalidWindow wrapped with details, or any storage error
+func (s *RoomService) GetReservationAnalytics(roomID string, opts ReservationOptions) (*models.ReservationAnalytics, error) {
+	from, to, err := s.resolveReservationRange(roomID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.repo.GetReservations(roomID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %v", err)
+	}
+
+	analytics := calculateReservationAnalytics(reservations)
+	analytics.RoomID = roomID
+	analytics.From = from.Format(dateLayout)
+	analytics.To = to.Format(dateLayout)
+
+	return analytics, nil
+}
+
+// resolveReservationRange applies defaults to and validates a check-in range.
+// Parameters:
+//   - roomID string: Room whose local date the default range is centered on
+//   - opts ReservationOptions: Requested range
+//
+// Returns:
+//   - time.Time: First check-in day
+//   - time.Time: Last check-in day
+//   - error: ErrInvalidWindow wrapped with details when validation fails
+func (s *RoomService) resolveReservationRange(roomID string, opts ReservationOptions) (time.Time, time.Time, error) {
+	today, err := s.roomToday(roomID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from := today.AddDate(0, 0, -defaultReservationRangeDays)
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+	to := today.AddDate(0, 0, defaultReservationRangeDays)
+	if !opts.To.IsZero() {
+		to = truncateToDay(opts.To)
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to must not be before from", ErrInvalidWindow)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxReservationRangeDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: the range can be at most %d days", ErrInvalidWindow, maxReservationRangeDays)
+	}
+
+	return from, to, nil
+}
+
+// calculateReservationAnalytics aggregates stay statistics of reservations.
+// Parameters:
+//   - reservations []models.Reservation: Reservations to analyze
+//
+// Returns:
+//   - *models.ReservationAnalytics: Statistics without room and range
+func calculateReservationAnalytics(reservations []models.Reservation) *models.ReservationAnalytics {
+	analytics := &models.ReservationAnalytics{Reservations: len(reservations)}
+
+	var stays, leadTimes []float64
+	var guests int
+	var checkInDays [7]int
+	channels := make(map[string]int)
+	for _, reservation := range reservations {
+		checkIn, err := time.Parse(dateLayout, reservation.CheckIn)
+		if err != nil {
+			continue
+		}
+		checkOut, err := time.Parse(dateLayout, reservation.CheckOut)
+		if err != nil {
+			continue
+		}
+
+		nights := int(checkOut.Sub(checkIn).Hours() / 24)
+		leadTime := math.Max(0, checkIn.Sub(truncateToDay(reservation.BookedAt)).Hours()/24)
+
+		analytics.Nights += nights
+		stays = append(stays, float64(nights))
+		leadTimes = append(leadTimes, leadTime)
+		guests += reservation.GuestCount
+		checkInDays[checkIn.Weekday()]++
+		channels[reservation.Channel]++
+	}
+
+	analytics.LengthOfStay = rangeDistribution(stays, lengthOfStayRanges)
+	analytics.LeadTime = rangeDistribution(leadTimes, leadTimeRanges)
+	analytics.CheckInDayOfWeek = make([]models.DistributionBucket, 0, len(weekOrder))
+	for _, day := range weekOrder {
+		analytics.CheckInDayOfWeek = append(analytics.CheckInDayOfWeek,
+			distributionBucket(strings.ToLower(day.String()), checkInDays[day], len(stays)))
+	}
+	analytics.Channels = make([]models.DistributionBucket, 0, len(channels))
+	for channel, count := range channels {
+		analytics.Channels = append(analytics.Channels, distributionBucket(channel, count, len(stays)))
+	}
+	sort.Slice(analytics.Channels, func(i, j int) bool {
+		if analytics.Channels[i].Count != analytics.Channels[j].Count {
+			return analytics.Channels[i].Count > analytics.Channels[j].Count
+		}
+		return analytics.Channels[i].Label < analytics.Channels[j].Label
+	})
+
+	if len(stays) == 0 {
+		return analytics
+	}
+
+	n := float64(len(stays))
+	analytics.AverageLengthOfStay = round(float64(analytics.Nights) / n)
+	analytics.AverageGuestCount = round(float64(guests) / n)
+
+	var totalLeadTime float64
+	for _, leadTime := range leadTimes {
+		totalLeadTime += leadTime
+	}
+	analytics.AverageLeadTime = round(totalLeadTime / n)
+
+	sort.Float64s(stays)
+	sort.Float64s(leadTimes)
+	analytics.MedianLengthOfStay = round(percentile(stays, 50))
+	analytics.MedianLeadTime = round(percentile(leadTimes, 50))
+
+	return analytics
+}
+
+// rangeDistribution counts values per range.
+// Parameters:
+//   - values []float64: Values to categorize
+//   - ranges []distributionRange: Ranges in ascending order, the last one open ended
+//
+// Returns:
+//   - []models.DistributionBucket: One bucket per range, including empty ones
+func rangeDistribution(values []float64, ranges []distributionRange) []models.DistributionBucket {
+	counts := make([]int, len(ranges))
+	for _, value := range values {
+		for i, r := range ranges {
+			if value <= float64(r.max) {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	buckets := make([]models.DistributionBucket, 0, len(ranges))
+	for i, r := range ranges {
+		buckets = append(buckets, distributionBucket(r.label, counts[i], len(values)))
+	}
+	return buckets
+}
+
+// distributionBucket builds a bucket with its share of the total.
+// Parameters:
+//   - label string: Category name
+//   - count int: Reservations in the category
+//   - total int: All reservations
+//
+// Returns:
+//   - models.DistributionBucket: Bucket with percentage, zero if total is zero
+func distributionBucket(label string, count, total int) models.DistributionBucket {
+	bucket := models.DistributionBucket{Label: label, Count: count}
+	if total > 0 {
+		bucket.Percentage = round(float64(count) / float64(total) * 100)
+	}
+	return bucket
+}
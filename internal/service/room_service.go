@@ -10,61 +10,168 @@ import (
 // RoomService handles room analytics operations and database interactions.
 // It processes raw booking data to generate occupancy and rate analytics.
 type RoomService struct {
-	repo *repository.RoomRepository
+	repo  repository.RoomRepository
+	clock Clock
 }
 
 // NewRoomService creates and returns a new RoomService instance
-// with configured repository.
+// with configured repository. The wall clock is used unless
+// overridden with WithClock.
+// Parameters:
+//   - repo repository.RoomRepository: Storage backend for room data
+//   - opts ...Option: Optional dependencies to override
+//
 // Returns:
 //   - *RoomService: New room service instance configured with repository
-func NewRoomService() *RoomService {
-	return &RoomService{
-		repo: repository.NewRoomRepository(),
+func NewRoomService(repo repository.RoomRepository, opts ...Option) *RoomService {
+	s := &RoomService{
+		repo:  repo,
+		clock: systemClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// today returns the current UTC calendar date according to the service clock.
+// Returns:
+//   - time.Time: Midnight UTC of the current date
+func (s *RoomService) today() time.Time {
+	return s.todayIn(time.UTC)
+}
+
+// todayIn returns the current calendar date in a time zone according to the
+// service clock.
+// Parameters:
+//   - location *time.Location: Time zone whose calendar to use
+//
+// Returns:
+//   - time.Time: The local date, as midnight UTC like all other dates
+func (s *RoomService) todayIn(location *time.Location) time.Time {
+	return truncateToDay(s.clock.Now().In(location))
+}
+
+// roomToday returns the current calendar date in a room's time zone.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - time.Time: The room's local date, as midnight UTC
+//   - error: Any error encountered while looking up the room
+func (s *RoomService) roomToday(roomID string) (time.Time, error) {
+	location, err := s.roomLocation(roomID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.todayIn(location), nil
+}
+
+// roomLocation looks up the time zone of a room.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - *time.Location: The room's time zone, UTC for rooms without a record
+//   - error: Any error encountered while looking up the room
+func (s *RoomService) roomLocation(roomID string) (*time.Location, error) {
+	room, err := s.roomOrDefault(roomID)
+	if err != nil {
+		return nil, err
 	}
+	return loadTimeZone(room.TimeZone)
 }
 
 // GetRoomAnalytics retrieves and processes analytics data for a specific room.
-// It calculates occupancy rates for the next 5 months and rate analytics for
-// the next 30 days from the current date.
+// It calculates monthly occupancy rates and rate analytics over the windows
+// described by opts. By default occupancy covers the next 5 months and rates
+// the next 30 days, both starting today. "Today" is the current date in the
+// room's time zone according to the service clock unless opts.AsOf is set.
+// With opts.Compare the monthly occupancy and rate analytics include the same
+// metrics for the comparison period. With opts.Currency all rates are
+// converted before any metric is computed.
 // Parameters:
 //   - roomID string: Unique identifier for the room
+//   - opts AnalyticsOptions: Requested analysis windows, zero values select defaults
 //
 // Returns:
 //   - *models.AnalyticsResponse: Processed analytics data containing occupancy and rate statistics
 //   - error: Any error encountered during data retrieval or processing
-func (s *RoomService) GetRoomAnalytics(roomID string) (response *models.AnalyticsResponse, err error) {
-	// Get data for next 5 months from today
-	startDate := time.Now().Truncate(24 * time.Hour) // Start from beginning of today
-	endDate := startDate.AddDate(0, 5, 0)
+func (s *RoomService) GetRoomAnalytics(roomID string, opts AnalyticsOptions) (response *models.AnalyticsResponse, err error) {
+	room, err := s.roomOrDefault(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := loadTimeZone(room.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := s.todayIn(location)
+	if !opts.AsOf.IsZero() {
+		asOf = truncateToDay(opts.AsOf)
+	}
 
+	window, err := resolveWindow(opts, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	percentiles, err := resolvePercentiles(opts.Percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	weekend, err := resolveWeekendDays(opts.WeekendDays)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolveCurrency(opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate := window.fetchRange()
 	roomData, err := s.repo.GetRoomData(roomID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch room data: %v", err)
 	}
 
 	if len(roomData) == 0 {
-		return nil, fmt.Errorf("room not found")
+		return nil, ErrRoomNotFound
+	}
+
+	currency := room.Currency
+	if target != "" && target != currency {
+		converter, err := s.newCurrencyConverter(target, []string{currency}, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		for i := range roomData {
+			if err := converter.convert(&roomData[i], currency); err != nil {
+				return nil, err
+			}
+		}
+		currency = target
 	}
 
-	occupancy := calculateMonthlyOccupancy(roomData)
-	rateAnalytics := calculateRateAnalytics(roomData)
+	occupancy := calculateMonthlyOccupancy(roomData, window.occupancyStart, window.occupancyEnd)
+	rateAnalytics := calculateRateAnalytics(roomData, window.rateStart, window.rateEnd, percentiles)
+	addComparisons(roomData, window, occupancy, &rateAnalytics)
+	dayOfWeek, weekendSummary := calculateWeekdayBreakdown(roomData, window.occupancyStart, window.occupancyEnd, weekend)
+
+	analysisWindow := window.toModel()
+	analysisWindow.TimeZone = location.String()
 
 	return &models.AnalyticsResponse{
 		RoomID:           roomID,
+		Currency:         currency,
+		Window:           analysisWindow,
 		MonthlyOccupancy: occupancy,
 		RateAnalytics:    rateAnalytics,
+		DayOfWeek:        dayOfWeek,
+		WeekendSummary:   weekendSummary,
 	}, nil
 }
-
-// GetAllRooms retrieves a list of all available room IDs from the database.
-// This can be used to get an overview of all rooms in the system.
-// Returns:
-//   - []string: List of unique room identifiers
-//   - error: Any error encountered during the database operation
-func (s *RoomService) GetAllRooms() ([]string, error) {
-	roomIDs, err := s.repo.GetAllRoomIDs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch room IDs: %v", err)
-	}
-	return roomIDs, nil
-}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"airbnb-analytics/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRoom is returned when a room record fails validation
+var ErrInvalidRoom = errors.New("invalid room")
+
+// ErrRoomExists is returned when creating a room that already has a record
+var ErrRoomExists = errors.New("room already exists")
+
+// ListRooms retrieves the room records matching a filter.
+// Parameters:
+//   - filter models.RoomFilter: Attributes to match, zero values match any room
+//
+// Returns:
+//   - *models.RoomList: Matching rooms ordered by room ID
+//   - error: ErrInvalidRoom wrapped with details for an invalid filter, or any storage error
+func (s *RoomService) ListRooms(filter models.RoomFilter) (*models.RoomList, error) {
+	filter, err := normalizeRoomFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := s.repo.ListRooms(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rooms: %v", err)
+	}
+	if rooms == nil {
+		rooms = []models.Room{}
+	}
+
+	return &models.RoomList{Rooms: rooms}, nil
+}
+
+// GetRoom retrieves the record of a room.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - *models.Room: The room
+//   - error: ErrRoomNotFound if the room has no record, or any storage error
+func (s *RoomService) GetRoom(roomID string) (*models.Room, error) {
+	room, err := s.repo.GetRoom(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch room: %v", err)
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+	return room, nil
+}
+
+// normalizeRoomFilter trims and validates a room filter against the columns it matches.
+// Parameters:
+//   - filter models.RoomFilter: Filter to check
+//
+// Returns:
+//   - models.RoomFilter: Filter with trimmed text, a lower-case property type and an upper-case currency
+//   - error: ErrInvalidRoom wrapped with details of the first invalid attribute
+func normalizeRoomFilter(filter models.RoomFilter) (models.RoomFilter, error) {
+	filter.City = strings.TrimSpace(filter.City)
+	filter.Neighborhood = strings.TrimSpace(filter.Neighborhood)
+	filter.PropertyType = strings.ToLower(strings.TrimSpace(filter.PropertyType))
+	filter.Currency = strings.ToUpper(strings.TrimSpace(filter.Currency))
+
+	lengths := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"city", filter.City, models.MaxLocationLength},
+		{"neighborhood", filter.Neighborhood, models.MaxLocationLength},
+		{"property_type", filter.PropertyType, models.MaxPropertyTypeLength},
+	}
+	for _, field := range lengths {
+		if len(field.value) > field.max {
+			return filter, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidRoom, field.name, field.max)
+		}
+	}
+
+	if filter.Currency != "" && !models.ValidCurrency(filter.Currency) {
+		return filter, fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", ErrInvalidRoom)
+	}
+	if filter.MinBedrooms < 0 {
+		return filter, fmt.Errorf("%w: min_bedrooms must not be negative", ErrInvalidRoom)
+	}
+	if filter.MinCapacity < 0 {
+		return filter, fmt.Errorf("%w: min_capacity must not be negative", ErrInvalidRoom)
+	}
+
+	return filter, nil
+}
+
+// requireRoom checks that a room has a record.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record, or any storage error
+func (s *RoomService) requireRoom(roomID string) error {
+	room, err := s.repo.GetRoom(roomID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch room: %v", err)
+	}
+	if room == nil {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+// roomOrDefault retrieves the record of a room, falling back to default
+// attributes for rooms that have calendar data but no record.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - models.Room: The room's record or default attributes
+//   - error: Any storage error
+func (s *RoomService) roomOrDefault(roomID string) (models.Room, error) {
+	room, err := s.repo.GetRoom(roomID)
+	if err != nil {
+		return models.Room{}, fmt.Errorf("failed to fetch room: %v", err)
+	}
+	if room == nil {
+		return models.Room{
+			RoomID: roomID,
+			RoomAttributes: models.RoomAttributes{
+				Currency: models.DefaultCurrency,
+				TimeZone: models.DefaultTimeZone,
+			},
+		}, nil
+	}
+	return *room, nil
+}
+
+// loadTimeZone resolves a room's IANA time zone name.
+// Parameters:
+//   - name string: Time zone name, empty for UTC
+//
+// Returns:
+//   - *time.Location: The time zone
+//   - error: Error if the name is not a known time zone
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return location, nil
+}
+
+// CreateRoom validates and stores a new room record. The currency defaults
+// to models.DefaultCurrency and the time zone to models.DefaultTimeZone.
+// Parameters:
+//   - room models.Room: Room to create
+//
+// Returns:
+//   - *models.Room: The stored room
+//   - error: ErrInvalidRoom or ErrRoomExists wrapped with details, or any storage error
+func (s *RoomService) CreateRoom(room models.Room) (*models.Room, error) {
+	room.RoomID = strings.TrimSpace(room.RoomID)
+	if room.RoomID == "" {
+		return nil, fmt.Errorf("%w: room_id is required", ErrInvalidRoom)
+	}
+	if len(room.RoomID) > models.MaxRoomIDLength {
+		return nil, fmt.Errorf("%w: room_id must be at most %d characters", ErrInvalidRoom, models.MaxRoomIDLength)
+	}
+
+	attributes, err := normalizeRoomAttributes(room.RoomAttributes)
+	if err != nil {
+		return nil, err
+	}
+	room.RoomAttributes = attributes
+
+	if err := s.repo.CreateRoom(room); err != nil {
+		if errors.Is(err, repository.ErrRoomExists) {
+			return nil, fmt.Errorf("%w: %s", ErrRoomExists, room.RoomID)
+		}
+		return nil, fmt.Errorf("failed to create room: %v", err)
+	}
+
+	return &room, nil
+}
+
+// UpdateRoom validates and replaces the attributes of a room record.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//   - attributes models.RoomAttributes: New attributes, the currency and time zone default as on creation
+//
+// Returns:
+//   - *models.Room: The updated room
+//   - error: ErrInvalidRoom wrapped with details, ErrRoomNotFound if the room
+//     has no record, or any storage error
+func (s *RoomService) UpdateRoom(roomID string, attributes models.RoomAttributes) (*models.Room, error) {
+	attributes, err := normalizeRoomAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	room := models.Room{RoomID: roomID, RoomAttributes: attributes}
+	if err := s.repo.UpdateRoom(room); err != nil {
+		if errors.Is(err, repository.ErrRoomNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("failed to update room: %v", err)
+	}
+
+	return &room, nil
+}
+
+// DeleteRoom removes a room record together with its calendar, booking
+// history and reservations.
+// Parameters:
+//   - roomID string: Unique identifier for the room
+//
+// Returns:
+//   - error: ErrRoomNotFound if the room has no record, or any storage error
+func (s *RoomService) DeleteRoom(roomID string) error {
+	if err := s.repo.DeleteRoom(roomID); err != nil {
+		if errors.Is(err, repository.ErrRoomNotFound) {
+			return ErrRoomNotFound
+		}
+		return fmt.Errorf("failed to delete room: %v", err)
+	}
+	return nil
+}
+
+// normalizeRoomAttributes trims and validates room attributes against their columns.
+// Parameters:
+//   - attributes models.RoomAttributes: Attributes to check
+//
+// Returns:
+//   - models.RoomAttributes: Attributes with trimmed text and an upper-case currency
+//   - error: ErrInvalidRoom wrapped with details of the first invalid attribute
+func normalizeRoomAttributes(attributes models.RoomAttributes) (models.RoomAttributes, error) {
+	attributes.Name = strings.TrimSpace(attributes.Name)
+	attributes.City = strings.TrimSpace(attributes.City)
+	attributes.Neighborhood = strings.TrimSpace(attributes.Neighborhood)
+	attributes.PropertyType = strings.ToLower(strings.TrimSpace(attributes.PropertyType))
+	attributes.Currency = strings.ToUpper(strings.TrimSpace(attributes.Currency))
+	if attributes.Currency == "" {
+		attributes.Currency = models.DefaultCurrency
+	}
+	attributes.TimeZone = strings.TrimSpace(attributes.TimeZone)
+	if attributes.TimeZone == "" {
+		attributes.TimeZone = models.DefaultTimeZone
+	}
+
+	lengths := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"name", attributes.Name, models.MaxRoomNameLength},
+		{"city", attributes.City, models.MaxLocationLength},
+		{"neighborhood", attributes.Neighborhood, models.MaxLocationLength},
+		{"property_type", attributes.PropertyType, models.MaxPropertyTypeLength},
+		{"timezone", attributes.TimeZone, models.MaxTimeZoneLength},
+	}
+	for _, field := range lengths {
+		if len(field.value) > field.max {
+			return attributes, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidRoom, field.name, field.max)
+		}
+	}
+
+	if attributes.Bedrooms < 0 {
+		return attributes, fmt.Errorf("%w: bedrooms must not be negative", ErrInvalidRoom)
+	}
+	if attributes.Capacity < 0 {
+		return attributes, fmt.Errorf("%w: capacity must not be negative", ErrInvalidRoom)
+	}
+
+	if (attributes.Latitude == nil) != (attributes.Longitude == nil) {
+		return attributes, fmt.Errorf("%w: latitude and longitude must be given together", ErrInvalidRoom)
+	}
+	if attributes.Latitude != nil && (math.IsNaN(*attributes.Latitude) || math.Abs(*attributes.Latitude) > 90) {
+		return attributes, fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidRoom)
+	}
+	if attributes.Longitude != nil && (math.IsNaN(*attributes.Longitude) || math.Abs(*attributes.Longitude) > 180) {
+		return attributes, fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidRoom)
+	}
+
+	if !models.ValidCurrency(attributes.Currency) {
+		return attributes, fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", ErrInvalidRoom)
+	}
+	// Local is the server's zone, not a listing's
+	if _, err := loadTimeZone(attributes.TimeZone); err != nil || attributes.TimeZone == "Local" {
+		return attributes, fmt.Errorf("%w: timezone must be an IANA time zone name, e.g. Europe/Lisbon", ErrInvalidRoom)
+	}
+
+	return attributes, nil
+}
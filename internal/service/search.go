@@ -0,0 +1,303 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSearchLimit is the page size used when none is requested
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest page size that can be requested
+	MaxSearchLimit = 100
+	// maxSearchDays limits the metrics window and the availability range
+	maxSearchDays = 366
+)
+
+// searchSortKeys maps the supported sort orders to the metric they sort by.
+// Rooms with equal values are ordered by room ID.
+var searchSortKeys = map[string]func(models.RoomSearchMatch) float64{
+	models.SearchSortRoomID:       func(models.RoomSearchMatch) float64 { return 0 },
+	models.SearchSortOccupancy:    func(m models.RoomSearchMatch) float64 { return m.OccupancyPercentage },
+	models.SearchSortAverageRate:  func(m models.RoomSearchMatch) float64 { return m.AverageRate },
+	models.SearchSortADR:          func(m models.RoomSearchMatch) float64 { return m.ADR },
+	models.SearchSortRevPAR:       func(m models.RoomSearchMatch) float64 { return m.RevPAR },
+	models.SearchSortBookedNights: func(m models.RoomSearchMatch) float64 { return float64(m.BookedNights) },
+}
+
+// RoomSearchOptions controls the filters, order and page of SearchRooms.
+// Zero values select the defaults; nil bounds don't filter.
+type RoomSearchOptions struct {
+	models.RoomFilter
+	// From is the first day of the metrics window, defaults to today
+	From time.Time
+	// To is the last day of the metrics window, defaults to DefaultRateDays after From
+	To time.Time
+	// MinOccupancy and MaxOccupancy bound the occupancy percentage
+	MinOccupancy, MaxOccupancy *float64
+	// MinRate and MaxRate bound the average rate
+	MinRate, MaxRate *float64
+	// CheckIn and CheckOut select rooms available for a stay, with every night
+	// from check-in up to but excluding check-out known and unbooked; both or
+	// neither must be set
+	CheckIn, CheckOut time.Time
+	// Currency is the ISO 4217 code rates are converted to before filtering
+	Currency string
+	// Sort selects the metric to order by, one of the searchSortKeys, defaults to room_id
+	Sort string
+	// Descending reverses the order of the sort metric
+	Descending bool
+	// Limit is the page size, defaults to DefaultSearchLimit
+	Limit int
+	// Cursor continues a previous search from the end of its page
+	Cursor string
+}
+
+// searchCursor is the position of the last room of a page, encoded into NextCursor.
+type searchCursor struct {
+	Sort       string  `json:"s"`
+	Descending bool    `json:"d"`
+	Value      float64 `json:"v"`
+	RoomID     string  `json:"r"`
+}
+
+// SearchRooms finds rooms by attributes, occupancy and average rate over a
+// metrics window and availability for a stay. Matches are sorted by a metric
+// and returned in pages; the cursor of a page continues after its last room as
+// long as the other options stay the same. Filtering, sorting and paging run in
+// the repository, so only the rooms of the page are loaded.
+// Parameters:
+//   - opts RoomSearchOptions: Filters, order and page, zero values select defaults
+//
+// Returns:
+//   - *models.RoomSearchResult: The requested page of matching rooms
+//   - error: ErrInvalidRoom, ErrInvalidOptions, ErrInvalidWindow or
+//     ErrMissingExchangeRate wrapped with details, or any error encountered during data retrieval
+func (s *RoomService) SearchRooms(opts RoomSearchOptions) (*models.RoomSearchResult, error) {
+	from := s.today()
+	if !opts.From.IsZero() {
+		from = truncateToDay(opts.From)
+	}
+	to := from.AddDate(0, 0, DefaultRateDays-1)
+	if !opts.To.IsZero() {
+		to = truncateToDay(opts.To)
+	}
+	if err := validateSearchRange("to", from, to); err != nil {
+		return nil, err
+	}
+
+	filter, err := normalizeRoomFilter(opts.RoomFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkIn, checkOut time.Time
+	if !opts.CheckIn.IsZero() || !opts.CheckOut.IsZero() {
+		if checkIn, checkOut, _, err = validateStay(opts.CheckIn, opts.CheckOut); err != nil {
+			return nil, err
+		}
+	}
+
+	sortKey := opts.Sort
+	if sortKey == "" {
+		sortKey = models.SearchSortRoomID
+	}
+	metric, ok := searchSortKeys[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: sort must be one of %s", ErrInvalidOptions, strings.Join(searchSortNames(), ", "))
+	}
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidOptions, MaxSearchLimit)
+	}
+
+	query := models.RoomSearchQuery{
+		RoomFilter:   filter,
+		From:         from,
+		To:           to,
+		CheckIn:      checkIn,
+		CheckOut:     checkOut,
+		MinOccupancy: opts.MinOccupancy,
+		MaxOccupancy: opts.MaxOccupancy,
+		MinRate:      opts.MinRate,
+		MaxRate:      opts.MaxRate,
+		Sort:         sortKey,
+		Descending:   opts.Descending,
+		// One more room than requested tells whether another page follows
+		Limit: limit + 1,
+	}
+	if opts.Cursor != "" {
+		cursor, err := decodeSearchCursor(opts.Cursor)
+		if err != nil || cursor.Sort != sortKey || cursor.Descending != opts.Descending {
+			return nil, fmt.Errorf("%w: cursor is invalid or belongs to a different sort order", ErrInvalidOptions)
+		}
+		query.After = &models.RoomSearchPosition{Value: cursor.Value, RoomID: cursor.RoomID}
+	}
+
+	currency, err := resolveCurrency(opts.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.RoomSearchResult{
+		From:     from.Format(dateLayout),
+		To:       to.Format(dateLayout),
+		Currency: currency,
+		Rooms:    []models.RoomSearchMatch{},
+	}
+
+	if currency != "" {
+		if query.Factors, err = s.conversionFactors(filter, currency, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	matches, err := s.repo.SearchRooms(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search rooms: %v", err)
+	}
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+		last := matches[limit-1]
+		result.NextCursor = encodeSearchCursor(searchCursor{
+			Sort:       sortKey,
+			Descending: opts.Descending,
+			Value:      metric(last),
+			RoomID:     last.RoomID,
+		})
+	}
+	// The cursor keeps the unrounded metric the repository compares
+	for _, match := range matches {
+		match.OccupancyPercentage = round(match.OccupancyPercentage)
+		match.AverageRate = round(match.AverageRate)
+		match.ADR = round(match.ADR)
+		match.RevPAR = round(match.RevPAR)
+		result.Rooms = append(result.Rooms, match)
+	}
+
+	return result, nil
+}
+
+// conversionFactors computes the daily factors converting the rates of the
+// rooms matching a filter into a target currency.
+// Parameters:
+//   - filter models.RoomFilter: Attributes of the rooms whose currencies to convert
+//   - target string: ISO 4217 code of the currency to convert to
+//   - start time.Time: First day to convert
+//   - end time.Time: Last day to convert
+//
+// Returns:
+//   - []models.ConversionFactor: Factor of every other currency on every day
+//   - error: ErrMissingExchangeRate wrapped with details, or any storage error
+func (s *RoomService) conversionFactors(filter models.RoomFilter, target string, start, end time.Time) ([]models.ConversionFactor, error) {
+	rooms, err := s.repo.ListRooms(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rooms: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var sources []string
+	for _, room := range rooms {
+		if room.Currency != target && !seen[room.Currency] {
+			seen[room.Currency] = true
+			sources = append(sources, room.Currency)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	sort.Strings(sources)
+
+	converter, err := s.newCurrencyConverter(target, sources, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var factors []models.ConversionFactor
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		day := date.Format(dateLayout)
+		toRate, err := converter.rate(target, day)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			fromRate, err := converter.rate(source, day)
+			if err != nil {
+				return nil, err
+			}
+			factors = append(factors, models.ConversionFactor{Currency: source, Date: day, Factor: toRate / fromRate})
+		}
+	}
+	return factors, nil
+}
+
+// validateSearchRange checks that a date range is ordered and not too long.
+// Parameters:
+//   - name string: Parameter named in error messages
+//   - from time.Time: First day of the range
+//   - to time.Time: Last day of the range
+//
+// Returns:
+//   - error: ErrInvalidWindow wrapped with details if invalid
+func validateSearchRange(name string, from, to time.Time) error {
+	if to.Before(from) {
+		return fmt.Errorf("%w: %s must not be before the start of its range", ErrInvalidWindow, name)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxSearchDays {
+		return fmt.Errorf("%w: ranges can be at most %d days", ErrInvalidWindow, maxSearchDays)
+	}
+	return nil
+}
+
+// searchSortNames lists the supported sort orders alphabetically.
+// Returns:
+//   - []string: Sort order names
+func searchSortNames() []string {
+	names := make([]string, 0, len(searchSortKeys))
+	for name := range searchSortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// encodeSearchCursor serializes a page position into an opaque cursor.
+// Parameters:
+//   - cursor searchCursor: Position of the last room of a page
+//
+// Returns:
+//   - string: URL-safe cursor
+func encodeSearchCursor(cursor searchCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSearchCursor parses a cursor produced by encodeSearchCursor.
+// Parameters:
+//   - value string: Cursor from a previous page
+//
+// Returns:
+//   - *searchCursor: The decoded page position
+//   - error: Error if the cursor is malformed
+func decodeSearchCursor(value string) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor searchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchRoomsFilter(t *testing.T) {
+	repo := newMemoryRepository(t, nil)
+	for _, room := range []models.Room{
+		{RoomID: "FLAT", RoomAttributes: models.RoomAttributes{City: "Lisbon", PropertyType: "apartment", Currency: "USD", TimeZone: "UTC"}},
+		{RoomID: "HOUSE", RoomAttributes: models.RoomAttributes{City: "Porto", PropertyType: "house", Currency: "USD", TimeZone: "UTC"}},
+	} {
+		if err := repo.CreateRoom(room); err != nil {
+			t.Fatalf("creating room: %v", err)
+		}
+	}
+	if err := repo.UpsertBookings(append(monthBookings("FLAT", "2025-03", 10), monthBookings("HOUSE", "2025-03", 10)...), time.Now()); err != nil {
+		t.Fatalf("seeding bookings: %v", err)
+	}
+	s := newFixedService(t, repo, "2025-03-01")
+
+	tests := []struct {
+		name    string
+		filter  models.RoomFilter
+		want    []string
+		wantErr error
+	}{
+		{name: "filters are trimmed and case-insensitive", filter: models.RoomFilter{City: " lisbon ", PropertyType: " Apartment", Currency: "usd "}, want: []string{"FLAT"}},
+		{name: "too long city", filter: models.RoomFilter{City: strings.Repeat("a", models.MaxLocationLength+1)}, wantErr: ErrInvalidRoom},
+		{name: "too long property type", filter: models.RoomFilter{PropertyType: strings.Repeat("a", models.MaxPropertyTypeLength+1)}, wantErr: ErrInvalidRoom},
+		{name: "invalid currency", filter: models.RoomFilter{Currency: "EURO"}, wantErr: ErrInvalidRoom},
+		{name: "negative bedrooms", filter: models.RoomFilter{MinBedrooms: -1}, wantErr: ErrInvalidRoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.SearchRooms(RoomSearchOptions{
+				RoomFilter: tt.filter,
+				From:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:         time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SearchRooms() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, match := range result.Rooms {
+				got = append(got, match.RoomID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchRooms() rooms = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchRoomsPagesByUnroundedMetrics(t *testing.T) {
+	// Both average rates round to 100, but A's is the higher one
+	var bookings []models.RoomBooking
+	for roomID, rates := range map[string][]float64{"A": {100, 100.01, 100.01}, "B": {100, 100, 100.01}} {
+		for i, rate := range rates {
+			date := time.Date(2025, 3, 1+i, 0, 0, 0, 0, time.UTC).Format(dateLayout)
+			bookings = append(bookings, models.RoomBooking{RoomID: roomID, RoomData: models.RoomData{Date: date, Rate: rate}})
+		}
+	}
+	s := newFixedService(t, newMemoryRepository(t, bookings), "2025-03-01")
+
+	opts := RoomSearchOptions{
+		From:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		Sort:  models.SearchSortAverageRate,
+		Limit: 1,
+	}
+	var got []string
+	for page := 0; page < 3; page++ {
+		result, err := s.SearchRooms(opts)
+		if err != nil {
+			t.Fatalf("SearchRooms() error = %v", err)
+		}
+		for _, match := range result.Rooms {
+			if match.AverageRate != 100 {
+				t.Errorf("AverageRate of %s = %v, want 100", match.RoomID, match.AverageRate)
+			}
+			got = append(got, match.RoomID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		opts.Cursor = result.NextCursor
+	}
+
+	if want := []string{"B", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rooms = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultWeekendDays are treated as weekend when no weekend days are requested
+var DefaultWeekendDays = []time.Weekday{time.Saturday, time.Sunday}
+
+// weekOrder lists weekdays in the order they are reported, Monday first
+var weekOrder = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// ParseWeekday parses a weekday name, either in full ("friday") or
+// abbreviated to three letters ("fri"), case-insensitively.
+// Parameters:
+//   - name string: Weekday name
+//
+// Returns:
+//   - time.Weekday: Parsed weekday
+//   - error: ErrInvalidOptions wrapped with details if the name is unknown
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, day := range weekOrder {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown weekday %q", ErrInvalidOptions, name)
+}
+
+// resolveWeekendDays validates a requested set of weekend days.
+// Parameters:
+//   - days []time.Weekday: Requested weekend days, nil selects DefaultWeekendDays
+//
+// Returns:
+//   - map[time.Weekday]bool: Set of weekend days
+//   - error: ErrInvalidOptions wrapped with details when validation fails
+func resolveWeekendDays(days []time.Weekday) (map[time.Weekday]bool, error) {
+	if len(days) == 0 {
+		days = DefaultWeekendDays
+	}
+
+	weekend := make(map[time.Weekday]bool)
+	for _, day := range days {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("%w: invalid weekday %d", ErrInvalidOptions, day)
+		}
+		weekend[day] = true
+	}
+	if len(weekend) == len(weekOrder) {
+		return nil, fmt.Errorf("%w: at least one weekday must not be a weekend day", ErrInvalidOptions)
+	}
+
+	return weekend, nil
+}
+
+// nightTally accumulates night counts and rates for a group of days.
+type nightTally struct {
+	nights int
+	booked int
+	rates  float64
+}
+
+// add records a single night.
+func (t *nightTally) add(booking models.RoomData) {
+	t.nights++
+	t.rates += booking.Rate
+	if booking.IsBooked {
+		t.booked++
+	}
+}
+
+// occupancy returns the unrounded occupancy percentage of the tally.
+// Returns:
+//   - float64: Percentage of booked nights, zero without nights
+func (t nightTally) occupancy() float64 {
+	if t.nights == 0 {
+		return 0
+	}
+	return float64(t.booked) / float64(t.nights) * 100
+}
+
+// stats converts the tally into its API representation.
+// Returns:
+//   - models.NightStats: Counts, occupancy and average rate of the group
+func (t nightTally) stats() models.NightStats {
+	stats := models.NightStats{
+		Nights:       t.nights,
+		BookedNights: t.booked,
+	}
+	if t.nights > 0 {
+		stats.OccupancyPercentage = round(t.occupancy())
+		stats.AverageRate = round(t.rates / float64(t.nights))
+	}
+	return stats
+}
+
+// calculateWeekdayBreakdown processes room data to calculate occupancy and
+// average rate per day of week, plus a weekend versus weekday summary.
+// Parameters:
+//   - data []models.RoomData: Slice of room booking data
+//   - start time.Time: First day of the analyzed range
+//   - end time.Time: Last day of the analyzed range (inclusive)
+//   - weekend map[time.Weekday]bool: Days counted as weekend
+//
+// Returns:
+//   - []models.DayOfWeekStats: Statistics per weekday, Monday first
+//   - models.WeekendSummary: Weekend and weekday statistics
+func calculateWeekdayBreakdown(data []models.RoomData, start, end time.Time, weekend map[time.Weekday]bool) ([]models.DayOfWeekStats, models.WeekendSummary) {
+	var byDay [7]nightTally
+	var weekendTally, weekdayTally nightTally
+
+	for _, booking := range data {
+		bookingDate, err := time.Parse(dateLayout, booking.Date)
+		if err != nil || !inRange(bookingDate, start, end) {
+			continue
+		}
+
+		day := bookingDate.Weekday()
+		byDay[day].add(booking)
+		if weekend[day] {
+			weekendTally.add(booking)
+		} else {
+			weekdayTally.add(booking)
+		}
+	}
+
+	breakdown := make([]models.DayOfWeekStats, 0, len(weekOrder))
+	summary := models.WeekendSummary{
+		WeekendDays: []string{},
+		Weekend:     weekendTally.stats(),
+		Weekday:     weekdayTally.stats(),
+	}
+	for _, day := range weekOrder {
+		breakdown = append(breakdown, models.DayOfWeekStats{
+			Day:        strings.ToLower(day.String()),
+			NightStats: byDay[day].stats(),
+		})
+		if weekend[day] {
+			summary.WeekendDays = append(summary.WeekendDays, strings.ToLower(day.String()))
+		}
+	}
+
+	return breakdown, summary
+}
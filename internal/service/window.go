@@ -0,0 +1,191 @@
+package service
+
+import (
+	"airbnb-analytics/internal/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// dateLayout is the layout used for all calendar dates exchanged with the API
+	dateLayout = "2006-01-02"
+
+	// DefaultOccupancyMonths is the occupancy horizon used when none is requested
+	DefaultOccupancyMonths = 5
+	// MaxOccupancyMonths is the longest occupancy horizon that can be requested
+	MaxOccupancyMonths = 24
+	// DefaultRateDays is the rate window length used when none is requested
+	DefaultRateDays = 30
+	// MaxRateDays is the longest rate window that can be requested
+	MaxRateDays = 365
+)
+
+var (
+	// ErrRoomNotFound is returned when no booking data exists for a room
+	ErrRoomNotFound = errors.New("room not found")
+	// ErrInvalidWindow is returned when the requested analysis window is invalid
+	ErrInvalidWindow = errors.New("invalid analysis window")
+)
+
+// AnalyticsOptions controls the date ranges used by GetRoomAnalytics.
+// Zero values select the defaults.
+type AnalyticsOptions struct {
+	// AsOf is the date analytics are computed as of, defaults to the service clock's today
+	AsOf time.Time
+	// From is the first day of the analysis, defaults to AsOf
+	From time.Time
+	// To is the last day of the occupancy window; mutually exclusive with OccupancyMonths
+	To time.Time
+	// OccupancyMonths is the occupancy horizon in months starting at From
+	OccupancyMonths int
+	// RateDays is the rate window length in days starting at From
+	RateDays int
+	// Percentiles selects the rate percentiles to report, defaults to DefaultPercentiles
+	Percentiles []float64
+	// WeekendDays selects the days counted as weekend, defaults to DefaultWeekendDays
+	WeekendDays []time.Weekday
+	// Compare selects a comparison period, ComparePrevious or CompareLastYear;
+	// empty disables comparisons
+	Compare string
+	// Currency is the ISO 4217 code rates are converted to using each day's
+	// exchange rate; empty keeps rates in the room's own currency
+	Currency string
+}
+
+// analysisWindow holds the resolved, validated date ranges for an analytics request.
+type analysisWindow struct {
+	asOf            time.Time
+	occupancyStart  time.Time
+	occupancyEnd    time.Time
+	occupancyMonths int
+	rateStart       time.Time
+	rateEnd         time.Time
+	rateDays        int
+	compare         string
+}
+
+// resolveWindow validates the options and resolves them into concrete date ranges.
+// Parameters:
+//   - opts AnalyticsOptions: Requested options
+//   - asOf time.Time: Date the analytics are computed as of, used when no start date is requested
+//
+// Returns:
+//   - analysisWindow: Resolved date ranges, both bounds inclusive
+//   - error: ErrInvalidWindow wrapped with details when validation fails
+func resolveWindow(opts AnalyticsOptions, asOf time.Time) (analysisWindow, error) {
+	start := truncateToDay(asOf)
+	if !opts.From.IsZero() {
+		start = truncateToDay(opts.From)
+	}
+
+	if opts.OccupancyMonths < 0 || opts.OccupancyMonths > MaxOccupancyMonths {
+		return analysisWindow{}, fmt.Errorf("%w: occupancy_months must be between 1 and %d", ErrInvalidWindow, MaxOccupancyMonths)
+	}
+	if opts.RateDays < 0 || opts.RateDays > MaxRateDays {
+		return analysisWindow{}, fmt.Errorf("%w: rate_days must be between 1 and %d", ErrInvalidWindow, MaxRateDays)
+	}
+
+	switch opts.Compare {
+	case "", ComparePrevious, CompareLastYear:
+	default:
+		return analysisWindow{}, fmt.Errorf("%w: compare must be %s or %s", ErrInvalidWindow, ComparePrevious, CompareLastYear)
+	}
+
+	window := analysisWindow{
+		asOf:           truncateToDay(asOf),
+		occupancyStart: start,
+		rateStart:      start,
+		rateDays:       DefaultRateDays,
+		compare:        opts.Compare,
+	}
+
+	if !opts.To.IsZero() {
+		if opts.OccupancyMonths != 0 {
+			return analysisWindow{}, fmt.Errorf("%w: to and occupancy_months cannot be combined", ErrInvalidWindow)
+		}
+		end := truncateToDay(opts.To)
+		if end.Before(start) {
+			return analysisWindow{}, fmt.Errorf("%w: to must not be before from", ErrInvalidWindow)
+		}
+		if end.After(start.AddDate(0, MaxOccupancyMonths, -1)) {
+			return analysisWindow{}, fmt.Errorf("%w: window must not exceed %d months", ErrInvalidWindow, MaxOccupancyMonths)
+		}
+		window.occupancyEnd = end
+	} else {
+		window.occupancyMonths = DefaultOccupancyMonths
+		if opts.OccupancyMonths != 0 {
+			window.occupancyMonths = opts.OccupancyMonths
+		}
+		window.occupancyEnd = start.AddDate(0, window.occupancyMonths, -1)
+	}
+
+	if opts.RateDays != 0 {
+		window.rateDays = opts.RateDays
+	}
+	window.rateEnd = start.AddDate(0, 0, window.rateDays-1)
+
+	return window, nil
+}
+
+// fetchRange returns the date range covering both the occupancy and rate
+// windows and, if requested, their comparison periods.
+// Returns:
+//   - time.Time: First day to fetch
+//   - time.Time: Last day to fetch
+func (w analysisWindow) fetchRange() (time.Time, time.Time) {
+	end := w.occupancyEnd
+	if w.rateEnd.After(end) {
+		end = w.rateEnd
+	}
+
+	start := w.occupancyStart
+	if w.compare != "" {
+		comparison := w.comparisonWindow()
+		if comparison.occupancyStart.Before(start) {
+			start = comparison.occupancyStart
+		}
+		if comparison.rateStart.Before(start) {
+			start = comparison.rateStart
+		}
+	}
+	return start, end
+}
+
+// toModel converts the window into its API representation.
+// Returns:
+//   - models.AnalysisWindow: Window with dates formatted as "YYYY-MM-DD"
+func (w analysisWindow) toModel() models.AnalysisWindow {
+	return models.AnalysisWindow{
+		AsOf:            w.asOf.Format(dateLayout),
+		From:            w.occupancyStart.Format(dateLayout),
+		To:              w.occupancyEnd.Format(dateLayout),
+		OccupancyMonths: w.occupancyMonths,
+		RateFrom:        w.rateStart.Format(dateLayout),
+		RateTo:          w.rateEnd.Format(dateLayout),
+		RateDays:        w.rateDays,
+		Compare:         w.compare,
+	}
+}
+
+// truncateToDay strips the time of day, returning midnight UTC of the same calendar date.
+// Parameters:
+//   - t time.Time: Time to truncate
+//
+// Returns:
+//   - time.Time: Midnight UTC of t's calendar date
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// inRange reports whether date falls within [start, end], both inclusive.
+// Parameters:
+//   - date time.Time: Date to check
+//   - start time.Time: First day of the range
+//   - end time.Time: Last day of the range
+//
+// Returns:
+//   - bool: True if date is within the range
+func inRange(date, start, end time.Time) bool {
+	return !date.Before(start) && !date.After(end)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"airbnb-analytics/internal/database"
+	"airbnb-analytics/internal/migrations"
 	"context"
 	"database/sql"
 	"fmt"
@@ -88,51 +90,30 @@ func checkAndCreateDatabase() (err error) {
 	return nil
 }
 
-// checkAndCreateTables verifies if required tables exist and creates them if not.
-// It creates the room_bookings table with necessary indexes for efficient querying.
+// migrateSchema brings the schema up to date by applying all pending migrations.
+// Databases created before migrations existed are adopted by the initial migration.
 //
 // Parameters:
 //   - db *sql.DB: Active database connection
+//   - driver string: Database driver, "postgres" or "sqlite"
 //
 // Returns:
-//   - error: Any error encountered during table verification or creation
-func checkAndCreateTables(db *sql.DB) error {
-	var exists bool
-	query := `
-       SELECT EXISTS (
-           SELECT 1 
-           FROM information_schema.tables 
-           WHERE table_name = 'room_bookings'
-       )`
-	err := db.QueryRow(query).Scan(&exists)
+//   - error: Any error encountered while migrating
+func migrateSchema(db *sql.DB, driver string) error {
+	migrator, err := migrations.New(db, driver)
 	if err != nil {
-		return fmt.Errorf("error checking table existence: %v", err)
+		return err
 	}
 
-	if !exists {
-		log.Println("Creating room_bookings table...")
-		query := `
-       CREATE TABLE room_bookings (
-           id SERIAL PRIMARY KEY,
-           room_id VARCHAR(50) NOT NULL,
-           date DATE NOT NULL,
-           is_booked BOOLEAN NOT NULL,
-           rate DECIMAL(10,2) NOT NULL,
-           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-           UNIQUE(room_id, date)
-       );
-       CREATE INDEX idx_room_bookings_room_id ON room_bookings(room_id);
-       CREATE INDEX idx_room_bookings_date ON room_bookings(date);
-       `
-
-		if _, err := db.Exec(query); err != nil {
-			return fmt.Errorf("error creating tables: %v", err)
-		}
-		log.Println("Table created successfully")
-	} else {
-		log.Println("Table room_bookings already exists")
+	applied, err := migrator.Up()
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
 	}
 
+	log.Println("Schema is up to date")
 	return nil
 }
 
@@ -167,7 +148,7 @@ func generateDates() []time.Time {
 	return dates
 }
 
-// generateMockData creates and inserts mock booking data for rooms.
+// generateMockData creates and inserts mock rooms and their booking data.
 // It generates random room IDs, sets varying rates and booking status,
 // and inserts this data into the database for a 7-month period.
 //
@@ -187,6 +168,17 @@ func generateMockData(db *sql.DB) []string {
 	dates := generateDates()
 
 	for _, roomID := range roomIDs {
+		roomQuery := `
+           INSERT INTO rooms (room_id, name, bedrooms, capacity)
+           VALUES ($1, $2, $3, $4)
+           ON CONFLICT (room_id) DO NOTHING
+           `
+		bedrooms := rand.Intn(4) + 1
+		if _, err := db.Exec(roomQuery, roomID, "Room "+roomID, bedrooms, bedrooms*2); err != nil {
+			log.Printf("Error inserting room %s: %v", roomID, err)
+			continue
+		}
+
 		baseRate := 80.0 + rand.Float64()*120.0
 
 		for _, date := range dates {
@@ -200,7 +192,7 @@ func generateMockData(db *sql.DB) []string {
            ON CONFLICT (room_id, date) DO NOTHING
            `
 
-			if _, err := db.Exec(query, roomID, date, isBooked, dailyRate); err != nil {
+			if _, err := db.Exec(query, roomID, date.Format("2006-01-02"), isBooked, dailyRate); err != nil {
 				log.Printf("Error inserting data for room %s on %s: %v", roomID, date.Format("2006-01-02"), err)
 				continue
 			}
@@ -227,17 +219,15 @@ func round(num float64) float64 {
 	return float64(int(num*100)) / 100
 }
 
-// main is the entry point of the database setup script.
-// It performs the following operations in order:
-// 1. Loads environment variables
-// 2. Creates database if it doesn't exist
-// 3. Establishes database connection
-// 4. Creates necessary tables
-// 5. Generates and inserts mock data
-// 6. Prints generated room IDs
-func main() {
+// openPostgres prepares the PostgreSQL database and returns a connection to it.
+// It creates the database if it doesn't exist.
+//
+// Returns:
+//   - *sql.DB: Open database connection
+//   - error: Any error encountered during setup
+func openPostgres() (*sql.DB, error) {
 	if err := checkAndCreateDatabase(); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	connectionString := fmt.Sprintf(
@@ -251,7 +241,36 @@ func main() {
 
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
-		log.Fatal("Error connecting to database:", err)
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// main is the entry point of the database setup script.
+// It performs the following operations in order:
+// 1. Loads environment variables
+// 2. Creates database if it doesn't exist (PostgreSQL only)
+// 3. Establishes database connection
+// 4. Applies pending schema migrations
+// 5. Generates and inserts mock data
+// 6. Prints generated room IDs
+//
+// Setting DB_DRIVER=sqlite targets the SQLite file at DB_PATH instead of PostgreSQL.
+func main() {
+	driver := os.Getenv("DB_DRIVER")
+
+	var db *sql.DB
+	if driver == "sqlite" {
+		if err := database.InitSQLite(); err != nil {
+			log.Fatal(err)
+		}
+		db = database.DB
+	} else {
+		var err error
+		if db, err = openPostgres(); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	defer func() {
@@ -261,7 +280,7 @@ func main() {
 		}
 	}()
 
-	if err := checkAndCreateTables(db); err != nil {
+	if err := migrateSchema(db, driver); err != nil {
 		log.Fatal(err)
 	}
 